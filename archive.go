@@ -0,0 +1,573 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/bzip2"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"ktkr.us/pkg/fmtutil"
+)
+
+// Archives are browsed like directories. A path that runs through an archive
+// file, like /data/bundle.zip/inner/dir, names something inside it, and the
+// archive's own path with a trailing slash, /data/bundle.zip/, names its top
+// level. Without the slash the archive is just a file to download.
+
+const archiveCacheSize = 32
+
+// Archives are only browsed up to these limits, so that one crafted to
+// decompress to something huge can't tie the server up. archiveMaxRead is how
+// much of a compressed tar stream is read through to index it.
+const (
+	archiveMaxEntries = 100000
+	archiveMaxRead    = 4 << 30
+)
+
+var (
+	errArchiveMethod   = errors.New("archive: unsupported compression method")
+	errArchiveEntries  = fmt.Errorf("more than %d entries, too many to browse", archiveMaxEntries)
+	errArchiveTooLarge = fmt.Errorf("more than %v uncompressed, too large to browse", fmtutil.SI(archiveMaxRead))
+)
+
+type archiveKind int
+
+const (
+	notArchive archiveKind = iota
+	zipArchive
+	tarArchive
+	tgzArchive
+	tbzArchive
+)
+
+// archiveKindOf tells archives apart by file name, the same way a browser
+// would pick a program to open them with.
+func archiveKindOf(name string) archiveKind {
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return zipArchive
+	case strings.HasSuffix(name, ".tar"):
+		return tarArchive
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return tgzArchive
+	case strings.HasSuffix(name, ".tar.bz2"), strings.HasSuffix(name, ".tbz2"), strings.HasSuffix(name, ".tbz"):
+		return tbzArchive
+	}
+	return notArchive
+}
+
+// isArchive reports whether name looks like an archive that can be browsed.
+func isArchive(name string) bool {
+	return Conf.ArchiveBrowse && archiveKindOf(name) != notArchive
+}
+
+// browsePath returns the URL path that lists p: the path itself for
+// directories, or with a trailing slash for archives.
+func browsePath(p string) string {
+	if p != "/" && isArchive(path.Base(p)) {
+		return p + "/"
+	}
+	return p
+}
+
+// archiveStorage wraps a Storage so that archive files in it can be opened as
+// directories.
+type archiveStorage struct {
+	Storage
+
+	mu       sync.Mutex
+	cache    map[string]*archiveIndex
+	order    []string // cache keys, least recently built first
+	building map[string]*archiveBuild
+}
+
+// An archiveBuild is an index being read, which requests for the same archive
+// wait for rather than reading it again.
+type archiveBuild struct {
+	done    chan struct{}
+	size    int64
+	modTime time.Time
+	idx     *archiveIndex
+	err     error
+}
+
+func newArchiveStorage(s Storage) *archiveStorage {
+	return &archiveStorage{
+		Storage:  s,
+		cache:    make(map[string]*archiveIndex),
+		building: make(map[string]*archiveBuild),
+	}
+}
+
+func (s *archiveStorage) Open(name string) (http.File, error) {
+	arch, inner, fi, ok := s.split(name)
+	if !ok {
+		return s.Storage.Open(name)
+	}
+
+	idx, err := s.index(arch, fi)
+	if err != nil {
+		return nil, err
+	}
+	return idx.open(inner)
+}
+
+// LocalPath returns "" for anything inside an archive, since it isn't a file
+// of its own on disk.
+func (s *archiveStorage) LocalPath(name string) string {
+	if _, _, _, ok := s.split(name); ok {
+		return ""
+	}
+	return s.Storage.LocalPath(name)
+}
+
+// split finds the archive in the path name leads through and the path inside
+// it. Only path elements named like archives are checked against the
+// underlying storage, so ordinary paths cost nothing.
+func (s *archiveStorage) split(name string) (arch, inner string, fi os.FileInfo, ok bool) {
+	if !Conf.ArchiveBrowse {
+		return "", "", nil, false
+	}
+
+	elems := strings.Split(strings.TrimPrefix(name, "/"), "/")
+	// the last element can't be an archive to look into, except with a
+	// trailing slash, which leaves an empty element after it
+	for i := 0; i < len(elems)-1; i++ {
+		if archiveKindOf(elems[i]) == notArchive {
+			continue
+		}
+		arch = path.Clean("/" + strings.Join(elems[:i+1], "/"))
+		fi, err := statStorage(s.Storage, arch)
+		if err != nil || !fi.Mode().IsRegular() {
+			continue
+		}
+		return arch, path.Clean("/" + strings.Join(elems[i+1:], "/")), fi, true
+	}
+	return "", "", nil, false
+}
+
+// index returns the table of contents of the archive at arch, reading it
+// only if it isn't cached or the archive has changed since. Only one request
+// at a time reads a given archive; the others wait for it to finish.
+func (s *archiveStorage) index(arch string, fi os.FileInfo) (*archiveIndex, error) {
+	s.mu.Lock()
+	if idx, ok := s.cache[arch]; ok && idx.size == fi.Size() && idx.modTime.Equal(fi.ModTime()) {
+		s.mu.Unlock()
+		return idx, nil
+	}
+	if b, ok := s.building[arch]; ok && b.size == fi.Size() && b.modTime.Equal(fi.ModTime()) {
+		s.mu.Unlock()
+		<-b.done
+		return b.idx, b.err
+	}
+	b := &archiveBuild{done: make(chan struct{}), size: fi.Size(), modTime: fi.ModTime()}
+	s.building[arch] = b
+	s.mu.Unlock()
+
+	b.idx, b.err = readArchiveIndex(s.Storage, arch, fi)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.building[arch] == b {
+		delete(s.building, arch)
+	}
+	close(b.done)
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	idx := b.idx
+	if _, ok := s.cache[arch]; !ok {
+		s.order = append(s.order, arch)
+		if len(s.order) > archiveCacheSize {
+			delete(s.cache, s.order[0])
+			s.order = s.order[1:]
+		}
+	}
+	s.cache[arch] = idx
+	return idx, nil
+}
+
+// archiveIndex is the table of contents of an archive, keyed by cleaned
+// absolute paths inside it.
+type archiveIndex struct {
+	s       Storage
+	arch    string
+	kind    archiveKind
+	size    int64
+	modTime time.Time
+	nodes   map[string]*archiveNode
+}
+
+type archiveNode struct {
+	info     os.FileInfo
+	children []*archiveNode // directories only
+
+	// where a member's data is: its offset in the archive (-1 if it has to
+	// be found by reading through a compressed stream), and for zip members
+	// how it is compressed and its compressed size
+	offset int64
+	method uint16
+	csize  int64
+}
+
+func readArchiveIndex(s Storage, arch string, fi os.FileInfo) (*archiveIndex, error) {
+	idx := &archiveIndex{
+		s:       s,
+		arch:    arch,
+		kind:    archiveKindOf(arch),
+		size:    fi.Size(),
+		modTime: fi.ModTime(),
+		nodes:   make(map[string]*archiveNode),
+	}
+	idx.nodes["/"] = &archiveNode{info: dirInfo{path.Base(arch), fi.ModTime()}}
+
+	f, err := s.Open(arch)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if idx.kind == zipArchive {
+		err = idx.readZip(f)
+	} else {
+		err = idx.readTar(f)
+	}
+	if err != nil {
+		return nil, errors.New("archive " + arch + ": " + err.Error())
+	}
+	return idx, nil
+}
+
+func (idx *archiveIndex) readZip(f http.File) error {
+	zr, err := zip.NewReader(readerAt(f), idx.size)
+	if err != nil {
+		return err
+	}
+	if len(zr.File) > archiveMaxEntries {
+		return errArchiveEntries
+	}
+	for _, zf := range zr.File {
+		fi := zf.FileInfo()
+		if fi.IsDir() {
+			idx.add(zf.Name, &archiveNode{info: fi})
+			continue
+		}
+		if !fi.Mode().IsRegular() {
+			continue
+		}
+		off, err := zf.DataOffset()
+		if err != nil {
+			continue
+		}
+		idx.add(zf.Name, &archiveNode{
+			info:   fi,
+			offset: off,
+			method: zf.Method,
+			csize:  int64(zf.CompressedSize64),
+		})
+	}
+	return nil
+}
+
+func (idx *archiveIndex) readTar(f http.File) error {
+	r, err := decompress(idx.kind, f)
+	if err != nil {
+		return err
+	}
+	if idx.kind != tarArchive {
+		// a plain tar is seeked through, but a compressed one has to be
+		// read to the end
+		r = &limitedReader{r, archiveMaxRead, errArchiveTooLarge}
+	}
+
+	tr := tar.NewReader(r)
+	for entries := 0; ; entries++ {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if entries == archiveMaxEntries {
+			return errArchiveEntries
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			idx.add(hdr.Name, &archiveNode{info: hdr.FileInfo()})
+		case tar.TypeReg:
+			n := &archiveNode{info: hdr.FileInfo(), offset: -1}
+			if idx.kind == tarArchive {
+				// tar.Reader reads exactly the header blocks, so the file
+				// is now positioned at the member's data
+				if n.offset, err = f.Seek(0, io.SeekCurrent); err != nil {
+					n.offset = -1
+				}
+			}
+			idx.add(hdr.Name, n)
+		}
+		// links and special files are left out: there is nothing to serve
+	}
+}
+
+// limitedReader is io.LimitedReader, except that it fails with err when the
+// limit is reached rather than ending as if that were all there is.
+type limitedReader struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		return 0, l.err
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
+
+// add records n at name, creating any parent directories the archive doesn't
+// have entries of its own for.
+func (idx *archiveIndex) add(name string, n *archiveNode) {
+	name = path.Clean("/" + name)
+	if name == "/" {
+		return
+	}
+
+	if old, ok := idx.nodes[name]; ok {
+		if !old.info.IsDir() || !n.info.IsDir() {
+			// a duplicate file entry; the later one wins, like extracting
+			// would, but keeps its place in its parent
+			*old = *n
+			return
+		}
+		// an explicit entry for a directory seen before through its contents
+		old.info = n.info
+		return
+	}
+
+	dir := path.Dir(name)
+	parent, ok := idx.nodes[dir]
+	if !ok {
+		parent = &archiveNode{info: dirInfo{path.Base(dir), idx.modTime}}
+		idx.add(dir, parent)
+	}
+	parent.children = append(parent.children, n)
+	idx.nodes[name] = n
+}
+
+func (idx *archiveIndex) open(name string) (http.File, error) {
+	n, ok := idx.nodes[name]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+
+	if n.info.IsDir() {
+		fis := make([]os.FileInfo, len(n.children))
+		for i, c := range n.children {
+			fis[i] = c.info
+		}
+		return &memDir{info: n.info, entries: fis}, nil
+	}
+
+	if n.offset >= 0 && (idx.kind != zipArchive || n.method == zip.Store) {
+		// stored as is, so it can be read from anywhere and served in
+		// ranges like any other file
+		f, err := idx.s.Open(idx.arch)
+		if err != nil {
+			return nil, err
+		}
+		return &sectionFile{io.NewSectionReader(readerAt(f), n.offset, n.info.Size()), f, n.info}, nil
+	}
+
+	if idx.kind == zipArchive && n.method != zip.Deflate {
+		return nil, errArchiveMethod
+	}
+	return &streamFile{open: func() (io.ReadCloser, error) { return idx.stream(name, n) }, info: n.info}, nil
+}
+
+// stream opens the compressed member n at name for reading from the start.
+func (idx *archiveIndex) stream(name string, n *archiveNode) (io.ReadCloser, error) {
+	f, err := idx.s.Open(idx.arch)
+	if err != nil {
+		return nil, err
+	}
+
+	if idx.kind == zipArchive {
+		fr := flate.NewReader(io.NewSectionReader(readerAt(f), n.offset, n.csize))
+		return multiCloser{fr, []io.Closer{fr, f}}, nil
+	}
+
+	r, err := decompress(idx.kind, f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			f.Close()
+			if err == io.EOF {
+				err = os.ErrNotExist
+			}
+			return nil, err
+		}
+		if path.Clean("/"+hdr.Name) == name {
+			closers := []io.Closer{f}
+			if c, ok := r.(io.Closer); ok {
+				closers = append(closers, c)
+			}
+			return multiCloser{tr, closers}, nil
+		}
+	}
+}
+
+func decompress(kind archiveKind, r io.Reader) (io.Reader, error) {
+	switch kind {
+	case tgzArchive:
+		return gzip.NewReader(r)
+	case tbzArchive:
+		return bzip2.NewReader(r), nil
+	}
+	return r, nil
+}
+
+// readerAt gives random access to f, which the files from every Storage
+// support one way or another.
+func readerAt(f http.File) io.ReaderAt {
+	if ra, ok := f.(io.ReaderAt); ok {
+		return ra
+	}
+	return &seekReaderAt{f: f}
+}
+
+type seekReaderAt struct {
+	mu sync.Mutex
+	f  io.ReadSeeker
+}
+
+func (r *seekReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.f.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return io.ReadFull(r.f, p)
+}
+
+type multiCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (m multiCloser) Close() error {
+	var err error
+	for _, c := range m.closers {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// sectionFile is an uncompressed archive member.
+type sectionFile struct {
+	*io.SectionReader
+	io.Closer
+	info os.FileInfo
+}
+
+func (f *sectionFile) Stat() (os.FileInfo, error) { return f.info, nil }
+
+func (f *sectionFile) Readdir(int) ([]os.FileInfo, error) {
+	return nil, errors.New("archive: not a directory")
+}
+
+// streamFile is a compressed archive member. It can only really be read from
+// start to end, so seeking backwards starts over from the beginning. Seeking
+// is lazy, so finding out the size the way http.ServeContent does costs
+// nothing.
+type streamFile struct {
+	open func() (io.ReadCloser, error)
+	info os.FileInfo
+
+	rc   io.ReadCloser
+	pos  int64 // where the next Read reads from
+	rpos int64 // where rc is
+}
+
+func (f *streamFile) Read(p []byte) (int, error) {
+	if f.pos >= f.info.Size() {
+		return 0, io.EOF
+	}
+
+	if f.rc == nil || f.pos < f.rpos {
+		if f.rc != nil {
+			f.rc.Close()
+			f.rc = nil
+		}
+		rc, err := f.open()
+		if err != nil {
+			return 0, err
+		}
+		f.rc, f.rpos = rc, 0
+	}
+	if f.pos > f.rpos {
+		n, err := io.CopyN(ioutil.Discard, f.rc, f.pos-f.rpos)
+		f.rpos += n
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.rc.Read(p)
+	f.pos += int64(n)
+	f.rpos += int64(n)
+	return n, err
+}
+
+func (f *streamFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekEnd:
+		offset += f.info.Size()
+	}
+	if offset < 0 {
+		return f.pos, errors.New("archive: negative position")
+	}
+	f.pos = offset
+	return f.pos, nil
+}
+
+func (f *streamFile) Close() error {
+	if f.rc == nil {
+		return nil
+	}
+	return f.rc.Close()
+}
+
+func (f *streamFile) Stat() (os.FileInfo, error) { return f.info, nil }
+
+func (f *streamFile) Readdir(int) ([]os.FileInfo, error) {
+	return nil, errors.New("archive: not a directory")
+}
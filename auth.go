@@ -0,0 +1,353 @@
+package main
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// errBadCredentials is returned when a request carries credentials that don't
+// check out, as opposed to carrying none at all.
+var errBadCredentials = errors.New("bad credentials")
+
+var (
+	authMu sync.RWMutex
+	acl    = &accessControl{} // nothing configured allows everything
+)
+
+// accessControl holds everything loaded from the auth files. It is replaced
+// wholesale on reload and never modified in place.
+type accessControl struct {
+	passwords map[string]string // user → htpasswd hash
+	tokens    map[string]string // bearer token → user
+	groups    map[string]map[string]bool
+	rules     []accessRule // longest prefix first
+}
+
+// An accessRule lists who may see everything at or below a path prefix. An
+// allow entry is a user name, "@group", or "*" for anyone at all.
+type accessRule struct {
+	prefix string
+	allow  []string
+}
+
+// enabled reports whether any credentials are configured, i.e. whether
+// anonymous requests are distinguishable from authenticated ones.
+func (a *accessControl) enabled() bool {
+	return len(a.passwords) > 0 || len(a.tokens) > 0
+}
+
+func (a *accessControl) allowed(user, p string) bool {
+	p = path.Clean("/" + p)
+
+	for _, r := range a.rules {
+		if !hasPathPrefix(p, r.prefix) {
+			continue
+		}
+		for _, who := range r.allow {
+			switch {
+			case who == "*":
+				return true
+			case strings.HasPrefix(who, "@"):
+				if user != "" && a.groups[who[1:]][user] {
+					return true
+				}
+			case who == user:
+				return true
+			}
+		}
+		return false
+	}
+
+	// without rules, being logged in (if logging in is possible at all) is
+	// enough
+	if len(a.rules) == 0 {
+		return user != "" || !a.enabled()
+	}
+	return false
+}
+
+// dummyHash is checked against for users that don't exist, so that they
+// take as long to turn away as ones with a wrong password.
+const dummyHash = "$2a$10$x8GL96uVI5bqyh4uNWM5geQV6I8KGxnzjBAI/O.ojeX0Icu01LadK"
+
+func (a *accessControl) checkPassword(user, pass string) bool {
+	hash, ok := a.passwords[user]
+	if !ok {
+		bcrypt.CompareHashAndPassword([]byte(dummyHash), []byte(pass))
+		return false
+	}
+
+	switch {
+	case strings.HasPrefix(hash, "$2"):
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(pass)) == nil
+	case strings.HasPrefix(hash, "{SHA}"):
+		sum := sha1.Sum([]byte(pass))
+		want := base64.StdEncoding.EncodeToString(sum[:])
+		return subtle.ConstantTimeCompare([]byte(hash[5:]), []byte(want)) == 1
+	case strings.HasPrefix(hash, apr1Magic):
+		salt := strings.SplitN(hash[len(apr1Magic):], "$", 2)[0]
+		return subtle.ConstantTimeCompare([]byte(hash), []byte(apr1(pass, salt))) == 1
+	}
+	return false
+}
+
+// apr1Magic starts the Apache MD5 hashes htpasswd makes by default.
+const apr1Magic = "$apr1$"
+
+// apr1 hashes pass with salt the way Apache's htpasswd -m does: MD5-crypt,
+// with its own magic string.
+func apr1(pass, salt string) string {
+	if len(salt) > 8 {
+		salt = salt[:8]
+	}
+
+	alt := md5.Sum([]byte(pass + salt + pass))
+	d := md5.New()
+	io.WriteString(d, pass+apr1Magic+salt)
+	for i := len(pass); i > 0; i -= 16 {
+		if i > 16 {
+			d.Write(alt[:])
+		} else {
+			d.Write(alt[:i])
+		}
+	}
+	for i := len(pass); i > 0; i >>= 1 {
+		if i&1 != 0 {
+			d.Write([]byte{0})
+		} else {
+			d.Write([]byte{pass[0]})
+		}
+	}
+	sum := d.Sum(nil)
+
+	// made slow on purpose
+	for i := 0; i < 1000; i++ {
+		d := md5.New()
+		if i&1 != 0 {
+			io.WriteString(d, pass)
+		} else {
+			d.Write(sum)
+		}
+		if i%3 != 0 {
+			io.WriteString(d, salt)
+		}
+		if i%7 != 0 {
+			io.WriteString(d, pass)
+		}
+		if i&1 != 0 {
+			d.Write(sum)
+		} else {
+			io.WriteString(d, pass)
+		}
+		sum = d.Sum(nil)
+	}
+
+	const itoa64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	out := []byte(apr1Magic + salt + "$")
+	to64 := func(v uint, n int) {
+		for ; n > 0; n-- {
+			out = append(out, itoa64[v&0x3f])
+			v >>= 6
+		}
+	}
+	for _, g := range [][3]int{{0, 6, 12}, {1, 7, 13}, {2, 8, 14}, {3, 9, 15}, {4, 10, 5}} {
+		to64(uint(sum[g[0]])<<16|uint(sum[g[1]])<<8|uint(sum[g[2]]), 4)
+	}
+	to64(uint(sum[11]), 2)
+	return string(out)
+}
+
+func (a *accessControl) checkToken(token string) (string, bool) {
+	for t, user := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return user, true
+		}
+	}
+	return "", false
+}
+
+type byPrefixLen []accessRule
+
+func (l byPrefixLen) Len() int           { return len(l) }
+func (l byPrefixLen) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
+func (l byPrefixLen) Less(i, j int) bool { return len(l[i].prefix) > len(l[j].prefix) }
+
+// hasPathPrefix reports whether p is prefix or somewhere below it.
+func hasPathPrefix(p, prefix string) bool {
+	return prefix == "/" || p == prefix || strings.HasPrefix(p, prefix+"/")
+}
+
+// loadAuth (re)reads the password, token and rules files named in the config.
+// The previous configuration stays in effect if any of them fail to load.
+func loadAuth() error {
+	a := &accessControl{
+		passwords: make(map[string]string),
+		tokens:    make(map[string]string),
+		groups:    make(map[string]map[string]bool),
+	}
+
+	if Conf.AuthPasswdFile != "" {
+		err := readFields(Conf.AuthPasswdFile, ":", func(fields []string) error {
+			if len(fields) != 2 {
+				return errors.New("expected user:hash")
+			}
+			hash := fields[1]
+			if !strings.HasPrefix(hash, "$2") && !strings.HasPrefix(hash, "{SHA}") && !strings.HasPrefix(hash, apr1Magic) {
+				return fmt.Errorf("user %s: unsupported hash (use bcrypt, APR1-MD5 or SHA1)", fields[0])
+			}
+			a.passwords[fields[0]] = hash
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if Conf.AuthTokenFile != "" {
+		err := readFields(Conf.AuthTokenFile, "", func(fields []string) error {
+			if len(fields) != 2 {
+				return errors.New("expected token and user")
+			}
+			a.tokens[fields[0]] = fields[1]
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if Conf.AuthRulesFile != "" {
+		err := readFields(Conf.AuthRulesFile, "", func(fields []string) error {
+			if fields[0] == "group" {
+				if len(fields) < 2 {
+					return errors.New("group needs a name")
+				}
+				members := make(map[string]bool)
+				for _, m := range fields[2:] {
+					members[m] = true
+				}
+				a.groups[fields[1]] = members
+				return nil
+			}
+
+			if !strings.HasPrefix(fields[0], "/") {
+				return fmt.Errorf("path %q must start with /", fields[0])
+			}
+			a.rules = append(a.rules, accessRule{path.Clean(fields[0]), fields[1:]})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		sort.Stable(byPrefixLen(a.rules))
+	}
+
+	authMu.Lock()
+	acl = a
+	authMu.Unlock()
+
+	return nil
+}
+
+// readFields calls fn with the fields of each non-blank, non-comment line of
+// a file. Fields are split on sep, or on whitespace if sep is empty.
+func readFields(file, sep string, fn func(fields []string) error) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var fields []string
+		if sep == "" {
+			fields = strings.Fields(line)
+		} else {
+			fields = strings.SplitN(line, sep, 2)
+		}
+
+		if err := fn(fields); err != nil {
+			return fmt.Errorf("%s:%d: %v", file, n, err)
+		}
+	}
+	return s.Err()
+}
+
+func currentACL() *accessControl {
+	authMu.RLock()
+	defer authMu.RUnlock()
+	return acl
+}
+
+// authenticate returns the user a request is authenticated as, or "" for
+// anonymous requests.
+func authenticate(r *http.Request) (string, error) {
+	a := currentACL()
+
+	h := r.Header.Get("Authorization")
+	if h == "" {
+		return "", nil
+	}
+
+	if user, pass, ok := r.BasicAuth(); ok {
+		if a.checkPassword(user, pass) {
+			return user, nil
+		}
+		return "", errBadCredentials
+	}
+
+	if strings.HasPrefix(h, "Bearer ") {
+		if user, ok := a.checkToken(strings.TrimSpace(h[len("Bearer "):])); ok {
+			return user, nil
+		}
+	}
+	return "", errBadCredentials
+}
+
+// authorize authenticates a request and checks that the user may access the
+// URL path p. If not, it returns the status code to respond with (setting the
+// authentication challenge if logging in might help) and false.
+func authorize(w http.ResponseWriter, r *http.Request, p string) (user string, code int, ok bool) {
+	user, err := authenticate(r)
+	if err == nil && currentACL().allowed(user, p) {
+		return user, 200, true
+	}
+
+	if err != nil || user == "" && currentACL().enabled() {
+		challenge(w)
+		return user, 401, false
+	}
+	return user, 403, false
+}
+
+// challenge asks the client to log in.
+func challenge(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", fmt.Sprintf("Basic realm=%q", Conf.AuthRealm))
+}
+
+// canAccess reports whether user may access the URL path p. Callers use it to
+// leave out things the user isn't allowed to see.
+func canAccess(user, p string) bool {
+	return currentACL().allowed(user, p)
+}
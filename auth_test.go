@@ -0,0 +1,278 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// authFiles writes the given password, token and rules files and loads them,
+// as the server would at startup. done puts the previous configuration back.
+func authFiles(t *testing.T, passwd, tokens, rules string) (done func()) {
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old, oldConf := currentACL(), Conf
+	done = func() {
+		authMu.Lock()
+		acl = old
+		authMu.Unlock()
+		Conf = oldConf
+		os.RemoveAll(dir)
+	}
+
+	write := func(name, content string) string {
+		if content == "" {
+			return ""
+		}
+		p := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	Conf.AuthPasswdFile = write("passwd", passwd)
+	Conf.AuthTokenFile = write("tokens", tokens)
+	Conf.AuthRulesFile = write("rules", rules)
+	if err := loadAuth(); err != nil {
+		done()
+		t.Fatal(err)
+	}
+	return done
+}
+
+const testRules = `
+# as in the README
+group staff alice bob
+
+/            *
+/private     @staff carol
+/private/hr  alice
+/team        @staff
+`
+
+func TestAllowed(t *testing.T) {
+	done := authFiles(t, "alice:{SHA}x\nbob:{SHA}x\ncarol:{SHA}x\ndave:{SHA}x\n", "", testRules)
+	defer done()
+
+	tests := []struct {
+		user, path string
+		want       bool
+	}{
+		{"", "/", true},
+		{"", "/public/a.txt", true},
+		{"", "/private", false},
+		{"dave", "/private/x", false},
+		{"carol", "/private", true},
+		{"carol", "/private/", true},
+		{"carol", "/private/x/y", true},
+		{"bob", "/private/x", true},     // through @staff
+		{"carol", "/privateer", true},   // not below /private, so /
+		{"carol", "/private/hr", false}, // the longest prefix wins
+		{"carol", "/private/hr/pay.csv", false},
+		{"carol", "/private/hrx", true},
+		{"alice", "/private/hr/pay.csv", true},
+		{"bob", "/private/hr", false},
+		{"bob", "/team/../private/hr", false}, // cleaned first
+		{"", "/team", false},
+		{"carol", "/team/notes", false},
+		{"bob", "/team/notes", true},
+	}
+	for _, tt := range tests {
+		if got := canAccess(tt.user, tt.path); got != tt.want {
+			t.Errorf("canAccess(%q, %q) = %v, want %v", tt.user, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestAllowedWithoutRules(t *testing.T) {
+	tests := []struct {
+		passwd string
+		user   string
+		want   bool
+	}{
+		// nothing configured lets everyone in
+		{"", "", true},
+		// once logging in is possible, it's needed
+		{"alice:{SHA}x\n", "", false},
+		{"alice:{SHA}x\n", "alice", true},
+	}
+	for _, tt := range tests {
+		done := authFiles(t, tt.passwd, "", "")
+		if got := canAccess(tt.user, "/a/b"); got != tt.want {
+			t.Errorf("with passwd %q, canAccess(%q) = %v, want %v", tt.passwd, tt.user, got, tt.want)
+		}
+		done()
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	bc, err := bcrypt.GenerateFromPassword([]byte("bcrypt pw"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha1.Sum([]byte("sha pw"))
+	passwd := "bc:" + string(bc) + "\n" +
+		"sha:{SHA}" + base64.StdEncoding.EncodeToString(sum[:]) + "\n" +
+		// from openssl passwd -apr1 -salt r31..... myPassword
+		"apr:$apr1$r31.....$HqJZimcKQFAMYayBlzkrA/\n"
+	done := authFiles(t, passwd, "", "")
+	defer done()
+
+	tests := []struct {
+		user, pass string
+		want       bool
+	}{
+		{"bc", "bcrypt pw", true},
+		{"bc", "bcrypt pW", false},
+		{"bc", "", false},
+		{"sha", "sha pw", true},
+		{"sha", "sha pw ", false},
+		{"apr", "myPassword", true},
+		{"apr", "mypassword", false},
+		{"nobody", "bcrypt pw", false},
+		{"", "", false},
+	}
+	a := currentACL()
+	for _, tt := range tests {
+		if got := a.checkPassword(tt.user, tt.pass); got != tt.want {
+			t.Errorf("checkPassword(%q, %q) = %v, want %v", tt.user, tt.pass, got, tt.want)
+		}
+	}
+}
+
+func TestApr1(t *testing.T) {
+	// from openssl passwd -apr1
+	tests := []struct{ pass, salt, want string }{
+		{"myPassword", "r31.....", "$apr1$r31.....$HqJZimcKQFAMYayBlzkrA/"},
+		{"a much longer password than sixteen bytes", "abcdefgh", "$apr1$abcdefgh$Eqv4oIyMsS.tjfvQCJYY1/"},
+		{"", "xy", "$apr1$xy$43..WIhbfuznGvwoCyUek/"},
+	}
+	for _, tt := range tests {
+		if got := apr1(tt.pass, tt.salt); got != tt.want {
+			t.Errorf("apr1(%q, %q) = %s, want %s", tt.pass, tt.salt, got, tt.want)
+		}
+	}
+}
+
+func TestAuthenticateToken(t *testing.T) {
+	done := authFiles(t, "", "3f8e0c1d9a7b  backup-bot\n", "")
+	defer done()
+
+	tests := []struct {
+		header string
+		user   string
+		err    error
+	}{
+		{"", "", nil},
+		{"Bearer 3f8e0c1d9a7b", "backup-bot", nil},
+		{"Bearer 3f8e0c1d9a7", "", errBadCredentials},
+		{"Bearer ", "", errBadCredentials},
+		{"Token 3f8e0c1d9a7b", "", errBadCredentials},
+	}
+	for _, tt := range tests {
+		r, _ := http.NewRequest("GET", "/", nil)
+		if tt.header != "" {
+			r.Header.Set("Authorization", tt.header)
+		}
+		user, err := authenticate(r)
+		if user != tt.user || err != tt.err {
+			t.Errorf("Authorization %q: got %q, %v; want %q, %v", tt.header, user, err, tt.user, tt.err)
+		}
+	}
+}
+
+func TestLoadAuthErrors(t *testing.T) {
+	tests := []struct{ passwd, tokens, rules string }{
+		{passwd: "alice\n"},
+		{passwd: "alice:$1$md5crypt$x\n"},
+		{passwd: "alice:plaintext\n"},
+		{tokens: "onlyatoken\n"},
+		{tokens: "tok alice extra\n"},
+		{rules: "relative alice\n"},
+		{rules: "group\n"},
+	}
+	for _, tt := range tests {
+		dir, err := ioutil.TempDir("", "auth")
+		if err != nil {
+			t.Fatal(err)
+		}
+		oldConf := Conf
+		Conf.AuthPasswdFile, Conf.AuthTokenFile, Conf.AuthRulesFile = "", "", ""
+		for _, f := range []struct {
+			conf    *string
+			content string
+		}{{&Conf.AuthPasswdFile, tt.passwd}, {&Conf.AuthTokenFile, tt.tokens}, {&Conf.AuthRulesFile, tt.rules}} {
+			if f.content != "" {
+				*f.conf = filepath.Join(dir, "f")
+				ioutil.WriteFile(*f.conf, []byte(f.content), 0644)
+			}
+		}
+		before := currentACL()
+		if err := loadAuth(); err == nil {
+			t.Errorf("%+v loaded without an error", tt)
+		}
+		if currentACL() != before {
+			t.Errorf("%+v replaced the configuration despite failing", tt)
+		}
+		Conf = oldConf
+		os.RemoveAll(dir)
+	}
+}
+
+func TestDeniedDirectoriesHidden(t *testing.T) {
+	_, _, treeDone := storedZipTree(t, map[string]string{
+		"top/a.txt":            "a",
+		"top/private/b.txt":    "b",
+		"top/private/hr/c.txt": "c",
+		"top/team/d.txt":       "d",
+	})
+	defer treeDone()
+	done := authFiles(t, "alice:{SHA}x\ncarol:{SHA}x\n", "", `
+group staff alice bob
+/                 *
+/top/private      @staff carol
+/top/private/hr   alice
+/top/team         @staff
+`)
+	defer done()
+
+	tests := []struct {
+		user string
+		want []string
+	}{
+		{"", []string{"top", "top/a.txt"}},
+		{"carol", []string{"top", "top/a.txt", "top/private", "top/private/b.txt"}},
+		{"alice", []string{"top", "top/a.txt", "top/private", "top/private/b.txt", "top/private/hr", "top/private/hr/c.txt", "top/team", "top/team/d.txt"}},
+	}
+	for _, tt := range tests {
+		entries, err := walk("/top", tt.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, e := range entries {
+			got = append(got, e.Name)
+		}
+		sort.Strings(got)
+		if len(got) != len(tt.want) {
+			t.Errorf("%q downloads %q, want %q", tt.user, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q downloads %q, want %q", tt.user, got, tt.want)
+				break
+			}
+		}
+	}
+}
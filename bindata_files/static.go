@@ -8,7 +8,7 @@ import (
 )
 
 func init() {
-	bindata.RegisterFile(filepath.Join("static", "i.css"), time.Unix(1493474826, 0), []byte("@charset \"utf-8\";\n\n* {\n\x09margin: 0;\n\x09padding: 0;\n\x09box-sizing: border-box;\n}\n\nbody {\n\x09font-family: clear sans, helvetica, meiryo, sans-serif;\n}\n\na {\n\x09color: #a42;\n\x09text-decoration: none;\n}\na:hover {\n\x09color: #e86;\n}\n\ntable {\n\x09border-collapse: collapse;\n}\ntable th {\n\x09text-align: left;\n\x09background: #f8f8f8;\n\x09border-bottom: 3px solid #eee;\n\x09text-transform: uppercase;\n\x09font-size: 14px;\n}\ntable th, table tr:nth-of-type(2n) td {\n\x09background: #f8f8f8;\n}\n\ntable#files {\n\x09width: 100%;\n}\ntable#files th {\n\x09padding: 8px;\n}\ntable#files td {\n\x09padding: 4px 8px;\n}\ntable#files tbody {\n\x09font-size: 15px;\n}\n\n#total {\n\x09padding: 4px 8px;\n\x09color: #888;\n\x09margin-bottom: 64px;\n\x09font-size: 15px;\n}\n\n#main {\n\x09width: 100%;\n\x09max-width: 1024px;\n\x09padding: 64px;\n\x09margin: 0 auto 64px;\n}\n\n.sort::after {\n\x09content: \"\xe2\x96\xb2\";\n\x09font-size: 12px;\n}\n.sort.rev::after {\n\x09content: \"\xe2\x96\xbc\";\n}\n.d .n a::after {\n\x09content: \"/\";\n}\n.d .s {\n\x09color: #888;\n}\n.s, .m, .p {\n\x09width: 1%;\n\x09white-space: nowrap;\n}\ntd.p {\n\x09font-family: menlo, dejavu sans mono, monaco, consolas, monospace;\n}\n\n.crumbs {\n\x09margin: 32px 0;\n\x09list-style-type: none;\n}\n\n.crumbs li {\n\x09display: inline;\n}\n\n.crumbs a {\n\x09display: inline-block;\n\x09height: 32px;\n\x09line-height: 32px;\n\x09padding: 0 8px;\n\x09margin: 0 4px 4px 0;\n\x09background: #f8f8f8;\n}\n.crumbs a:hover {\n\x09background: #c64;\n\x09color: #fff;\n}\n.crumbs li:last-of-type {\n\x09font-weight: 700;\n}\n\ntd.n > div {\n\x09white-space: nowrap;\n\x09overflow: hidden;\n\x09text-overflow: ellipsis;\n}\ntd.n a, .l .n a:before {\n\x09background-image: url('/static/icons.png');\n\x09background-size: 16px 160px;\n\x09background-repeat: no-repeat;\n}\ntd.n a {\n\x09background-position: 0 -16px;\n\x09padding-left: 22px;\n\x09height: 16px;\n\x09line-height: 15px;\n\x09display: inline-block;\n\x09position: relative;\n}\n.d  td.n a { background-position: 0 0 }\n.up td.n a { background-position: 0 -128px }\n.f a[href$=\".mp3\"],\x09.f a[href$=\".m4a\"],\x09.f a[href$=\".flac\"],\n.f a[href$=\".wav\"], .f a[href$=\".aac\"],\x09.f a[href$=\".ogg\"]\n{background-position:0 -32px}\n.f a[href$=\".mkv\"],\x09.f a[href$=\".m4v\"],\x09.f a[href$=\".mov\"],\n.f a[href$=\".avi\"],\x09.f a[href$=\".mp4\"],\x09.f a[href$=\".ogv\"]\n{background-position:0 -48px}\n.f a[href$=\".txt\"],\x09.f a[href$=\".rb\"],\x09.f a[href$=\".py\"],\n.f a[href$=\".pl\"],\x09.f a[href$=\".c\"],\x09.f a[href$=\".h\"],\n.f a[href$=\".go\"],\x09.f a[href$=\".tmpl\"],.f a[href$=\".md\"],\n.f a[href$=\".html\"],.f a[href$=\".css\"],\x09.f a[href$=\".js\"],\n.f a[href$=\"README\"],.f a[href$=\"Makefile\"],.f a[href$=\".erb\"],\n.f a[href$=\".ini\"],\x09.f a[href$=\".conf\"],.f a[href$=\".cc\"],\n.f a[href$=\".cpp\"],\x09.f a[href$=\".sql\"], .f a[href$=\".sh\"],\n.f a[href$=\".nfo\"]\n{background-position:0 -64px}\n.f a[href$=\".jpg\"],\x09.f a[href$=\".png\"],\x09.f a[href$=\".jpeg\"],\n.f a[href$=\".gif\"],\x09.f a[href$=\".tiff\"],.f a[href$=\".bmp\"],\n.f a[href$=\".psd\"],\x09.f a[href$=\".ico\"], .f a[href$=\".svg\"],\n.f a[href$=\".pgm\"], .f a[href$=\".pbm\"], .f a[href$=\".pdf\"]\n{background-position:0 -80px}\n.f a[href$=\".torrent\"]\n{background-position:0 -96px}\n.f a[href$=\".zip\"],\x09.f a[href$=\".tar\"],\x09.f a[href$=\".gz\"],\n.f a[href$=\".tbz\"],\x09.f a[href$=\".tgz\"],\x09.f a[href$=\".xz\"],\n.f a[href$=\".bz2\"],\x09.f a[href$=\".rar\"],\x09.f a[href$=\".7z\"],\n.f a[href$=\".lzma\"],.f a[href$=\".hqx\"],\x09.f a[href$=\".lzh\"]\n{background-position:0 -112px}\n\n.l .n a:before { /* symlinks */\n\x09background-position: 0 -144px;\n\x09width: 16px;\n\x09height: 16px;\n\x09display: block;\n\x09content: \"\";\n\x09position: absolute;\n\x09top: 0;\n\x09left: 0;\n}\n\narticle h1, article h2, article h3, article h4 {\n\x09margin: 1em 0 .5em;\n}\narticle h1 {\n\x09font-size: 32px;\n}\narticle h2 {\n\x09font-size: 26px;\n\x09font-weight: 400;\n}\narticle h3 {\n\x09font-size: 20px;\n}\narticle h4 {\n\x09font-size: 16px;\n}\narticle p, article ol, article ul, article li {\n\x09line-height: 1.5;\n\x09margin-bottom: .5em;\n}\narticle ul, article ol {\n\x09padding-left: 1em;\n}\narticle ul {\n\x09list-style-type: square;\n}\narticle pre {\n\x09background: #f8f8f8;\n\x09font-family: menlo, dejavu sans mono, monaco, consolas, monospace;\n\x09padding: 16px;\n\x09margin-bottom: .5em;\n\x09overflow-x: auto;\n}\narticle pre code {\n\x09padding: 0;\n}\narticle pre.readme {\n\x09font-size: 14px;\n\x09white-space: pre-wrap;\n}\narticle code {\n\x09background: #f8f8f8;\n\x09padding: 2px 4px;\n\x09tab-size: 4;\n}\narticle table {\n\x09border-collapse: collapse;\n\x09margin-bottom: .5em;\n}\narticle table th, article table td {\n\x09padding: 8px 16px;\n}\n\n.gallery {\n\x09margin-top: 64px;\n}\n\n.gallery-items {\n\x09margin-top: 32px;\n\x09display: -webkit-flex;\n\x09-webkit-justify-content: space-between;\n\x09-webkit-flex-wrap: wrap;\n\x09-webkit-align-items: flex-start;\n\x09-webkit-align-content: flex-start;\n\n\x09display: flex;\n\x09justify-content: space-between;\n\x09flex-wrap: wrap;\n\x09align-items: flex-start;\n\x09align-content: flex-start;\n}\n\n.gallery figure {\n\x09width: 150px;\n\x09height: 140px;\n\x09margin: auto;\n\x09flex-grow: 1;\n\x09-webkit-flex-grow: 1;\n\x09text-align: center;\n}\n.gallery figure img {\n\x09max-width: 100%;\n\x09height: auto;\n\x09max-height: 100px;\n}\n.gallery figure figcaption {\n\x09font-size: 10px;\n\x09color: #444;\n}\nfigcaption span.s {\n\x09color: #888;\n}\nnav.gallery-pagination {\n\x09width: 120px;\n\x09text-align: center;\n\x09margin: 0 auto;\n\x09padding: 4px 8px;\n\x09background: #f8f8f8;\n}\n\n.empty {\n\x09color: #888;\n\x09font-style: italic;\n\x09text-align: center;\n}\n\n@media screen and (max-width: 860px) {\n\x09.p {\n\x09\x09display: none;\n\x09}\n\x09#main {\n\x09\x09padding: 32px;\n\x09}\n}\n\n@media screen and (max-width: 767px) {\n\x09#main {\n\x09\x09padding: 16px 0 32px;\n\x09}\n\x09.n {\n\x09\x09width: 1%;\n\x09}\n\x09.crumbs {\n\x09\x09margin: 8px;\n\x09}\n}\n@media\nonly screen and (-webkit-min-device-pixel-ratio: 2), /* safari */\nonly screen and (min-device-pixel-ratio: 2), /* old version */\nonly screen and (min-resolution: 192dpi), /* IE 9..11 and opera mini */\nonly screen and (min-resolution: 2dppx) {  /* compliant */\n\x09td.n a, .l .n a:before {\n\x09\x09background-image: url('/static/icons@2x.png');\n\x09}\n}\n"))
+	bindata.RegisterFile(filepath.Join("static", "i.css"), time.Unix(1792282308, 0), []byte("@charset \"utf-8\";\n\n* {\n\x09margin: 0;\n\x09padding: 0;\n\x09box-sizing: border-box;\n}\n\nbody {\n\x09font-family: clear sans, helvetica, meiryo, sans-serif;\n}\n\na {\n\x09color: #a42;\n\x09text-decoration: none;\n}\na:hover {\n\x09color: #e86;\n}\n\ntable {\n\x09border-collapse: collapse;\n}\ntable th {\n\x09text-align: left;\n\x09background: #f8f8f8;\n\x09border-bottom: 3px solid #eee;\n\x09text-transform: uppercase;\n\x09font-size: 14px;\n}\ntable th, table tr:nth-of-type(2n) td {\n\x09background: #f8f8f8;\n}\n\ntable#files {\n\x09width: 100%;\n}\ntable#files th {\n\x09padding: 8px;\n}\ntable#files td {\n\x09padding: 4px 8px;\n}\ntable#files tbody {\n\x09font-size: 15px;\n}\n\n#total {\n\x09padding: 4px 8px;\n\x09color: #888;\n\x09margin-bottom: 64px;\n\x09font-size: 15px;\n}\n\nform.upload, form.selection {\n\x09margin: -48px 0 64px;\n\x09padding: 4px 8px;\n\x09font-size: 15px;\n\x09color: #888;\n}\nform.upload label {\n\x09margin-right: 16px;\n}\n\n#main {\n\x09width: 100%;\n\x09max-width: 1024px;\n\x09padding: 64px;\n\x09margin: 0 auto 64px;\n}\n\n.sort::after {\n\x09content: \"\xe2\x96\xb2\";\n\x09font-size: 12px;\n}\n.sort.rev::after {\n\x09content: \"\xe2\x96\xbc\";\n}\n.d .n a::after {\n\x09content: \"/\";\n}\n.d .s {\n\x09color: #888;\n}\n.c, .s, .m, .p {\n\x09width: 1%;\n\x09white-space: nowrap;\n}\ntd.p {\n\x09font-family: menlo, dejavu sans mono, monaco, consolas, monospace;\n}\n\n.crumbs {\n\x09margin: 32px 0;\n\x09list-style-type: none;\n}\n\n.crumbs li {\n\x09display: inline;\n}\n\n.crumbs a {\n\x09display: inline-block;\n\x09height: 32px;\n\x09line-height: 32px;\n\x09padding: 0 8px;\n\x09margin: 0 4px 4px 0;\n\x09background: #f8f8f8;\n}\n.crumbs a:hover {\n\x09background: #c64;\n\x09color: #fff;\n}\n.crumbs li:last-of-type {\n\x09font-weight: 700;\n}\n\nform.search {\n\x09margin: -16px 0 16px;\n}\nform.search input {\n\x09width: 100%;\n\x09padding: 6px 8px;\n\x09font: inherit;\n\x09font-size: 15px;\n\x09border: 1px solid #eee;\n\x09background: #f8f8f8;\n}\nform.search input:focus {\n\x09outline: none;\n\x09border-color: #e86;\n\x09background: #fff;\n}\n\ntd.n > div {\n\x09white-space: nowrap;\n\x09overflow: hidden;\n\x09text-overflow: ellipsis;\n}\ntd.n a, .l .n a:before {\n\x09background-image: url('/static/icons.png');\n\x09background-size: 16px 160px;\n\x09background-repeat: no-repeat;\n}\ntd.n a {\n\x09background-position: 0 -16px;\n\x09padding-left: 22px;\n\x09height: 16px;\n\x09line-height: 15px;\n\x09display: inline-block;\n\x09position: relative;\n}\n.d  td.n a { background-position: 0 0 }\n.up td.n a { background-position: 0 -128px }\n.f a[href$=\".mp3\"],\x09.f a[href$=\".m4a\"],\x09.f a[href$=\".flac\"],\n.f a[href$=\".wav\"], .f a[href$=\".aac\"],\x09.f a[href$=\".ogg\"]\n{background-position:0 -32px}\n.f a[href$=\".mkv\"],\x09.f a[href$=\".m4v\"],\x09.f a[href$=\".mov\"],\n.f a[href$=\".avi\"],\x09.f a[href$=\".mp4\"],\x09.f a[href$=\".ogv\"]\n{background-position:0 -48px}\n.f a[href$=\".txt\"],\x09.f a[href$=\".rb\"],\x09.f a[href$=\".py\"],\n.f a[href$=\".pl\"],\x09.f a[href$=\".c\"],\x09.f a[href$=\".h\"],\n.f a[href$=\".go\"],\x09.f a[href$=\".tmpl\"],.f a[href$=\".md\"],\n.f a[href$=\".html\"],.f a[href$=\".css\"],\x09.f a[href$=\".js\"],\n.f a[href$=\"README\"],.f a[href$=\"Makefile\"],.f a[href$=\".erb\"],\n.f a[href$=\".ini\"],\x09.f a[href$=\".conf\"],.f a[href$=\".cc\"],\n.f a[href$=\".cpp\"],\x09.f a[href$=\".sql\"], .f a[href$=\".sh\"],\n.f a[href$=\".nfo\"]\n{background-position:0 -64px}\n.f a[href$=\".jpg\"],\x09.f a[href$=\".png\"],\x09.f a[href$=\".jpeg\"],\n.f a[href$=\".gif\"],\x09.f a[href$=\".tiff\"],.f a[href$=\".bmp\"],\n.f a[href$=\".psd\"],\x09.f a[href$=\".ico\"], .f a[href$=\".svg\"],\n.f a[href$=\".pgm\"], .f a[href$=\".pbm\"], .f a[href$=\".pdf\"]\n{background-position:0 -80px}\n.f a[href$=\".torrent\"]\n{background-position:0 -96px}\n.f a[href$=\".zip\"],\x09.f a[href$=\".tar\"],\x09.f a[href$=\".gz\"],\n.f a[href$=\".tbz\"],\x09.f a[href$=\".tgz\"],\x09.f a[href$=\".xz\"],\n.f a[href$=\".bz2\"],\x09.f a[href$=\".rar\"],\x09.f a[href$=\".7z\"],\n.f a[href$=\".lzma\"],.f a[href$=\".hqx\"],\x09.f a[href$=\".lzh\"]\n{background-position:0 -112px}\ntd.n a.browse {\n\x09background: none;\n\x09padding-left: 0;\n\x09font-size: 0.85em;\n\x09color: #999;\n}\n\ntd.n span.target {\n\x09font-size: 0.85em;\n\x09color: #999;\n}\ntd.n span.error {\n\x09font-size: 0.85em;\n\x09color: #c33;\n}\n.broken td.n a:first-child {\n\x09color: #999;\n\x09text-decoration: line-through;\n}\n.refused td.n a:first-child {\n\x09color: #999;\n}\n\n.l .n a:before { /* symlinks */\n\x09background-position: 0 -144px;\n\x09width: 16px;\n\x09height: 16px;\n\x09display: block;\n\x09content: \"\";\n\x09position: absolute;\n\x09top: 0;\n\x09left: 0;\n}\n\narticle h1, article h2, article h3, article h4 {\n\x09margin: 1em 0 .5em;\n}\narticle h1 {\n\x09font-size: 32px;\n}\narticle h2 {\n\x09font-size: 26px;\n\x09font-weight: 400;\n}\narticle h3 {\n\x09font-size: 20px;\n}\narticle h4 {\n\x09font-size: 16px;\n}\narticle p, article ol, article ul, article li {\n\x09line-height: 1.5;\n\x09margin-bottom: .5em;\n}\narticle ul, article ol {\n\x09padding-left: 1em;\n}\narticle ul {\n\x09list-style-type: square;\n}\narticle pre {\n\x09background: #f8f8f8;\n\x09font-family: menlo, dejavu sans mono, monaco, consolas, monospace;\n\x09padding: 16px;\n\x09margin-bottom: .5em;\n\x09overflow-x: auto;\n}\narticle pre code {\n\x09padding: 0;\n}\narticle pre.readme {\n\x09font-size: 14px;\n\x09white-space: pre-wrap;\n}\narticle code {\n\x09background: #f8f8f8;\n\x09padding: 2px 4px;\n\x09tab-size: 4;\n}\narticle table {\n\x09border-collapse: collapse;\n\x09margin-bottom: .5em;\n}\narticle table th, article table td {\n\x09padding: 8px 16px;\n}\n\n.gallery {\n\x09margin-top: 64px;\n}\n\n.gallery-items {\n\x09margin-top: 32px;\n\x09display: -webkit-flex;\n\x09-webkit-justify-content: space-between;\n\x09-webkit-flex-wrap: wrap;\n\x09-webkit-align-items: flex-start;\n\x09-webkit-align-content: flex-start;\n\n\x09display: flex;\n\x09justify-content: space-between;\n\x09flex-wrap: wrap;\n\x09align-items: flex-start;\n\x09align-content: flex-start;\n}\n\n.gallery figure {\n\x09width: 150px;\n\x09height: 140px;\n\x09margin: auto;\n\x09flex-grow: 1;\n\x09-webkit-flex-grow: 1;\n\x09text-align: center;\n}\n.gallery figure img {\n\x09max-width: 100%;\n\x09height: auto;\n\x09max-height: 100px;\n}\n.gallery-medium figure {\n\x09width: 300px;\n\x09height: 240px;\n}\n.gallery-medium figure img {\n\x09max-height: 200px;\n}\n.gallery-large figure {\n\x09width: 600px;\n\x09height: 440px;\n}\n.gallery-large figure img {\n\x09max-height: 400px;\n}\n.gallery figure figcaption {\n\x09font-size: 10px;\n\x09color: #444;\n}\nfigcaption span.s {\n\x09color: #888;\n}\nnav.gallery-pagination {\n\x09width: 120px;\n\x09text-align: center;\n\x09margin: 0 auto;\n\x09padding: 4px 8px;\n\x09background: #f8f8f8;\n}\n\n.empty {\n\x09color: #888;\n\x09font-style: italic;\n\x09text-align: center;\n}\n\n@media screen and (max-width: 860px) {\n\x09.p {\n\x09\x09display: none;\n\x09}\n\x09#main {\n\x09\x09padding: 32px;\n\x09}\n}\n\n@media screen and (max-width: 767px) {\n\x09#main {\n\x09\x09padding: 16px 0 32px;\n\x09}\n\x09.n {\n\x09\x09width: 1%;\n\x09}\n\x09.crumbs {\n\x09\x09margin: 8px;\n\x09}\n}\n@media\nonly screen and (-webkit-min-device-pixel-ratio: 2), /* safari */\nonly screen and (min-device-pixel-ratio: 2), /* old version */\nonly screen and (min-resolution: 192dpi), /* IE 9..11 and opera mini */\nonly screen and (min-resolution: 2dppx) {  /* compliant */\n\x09td.n a, .l .n a:before {\n\x09\x09background-image: url('/static/icons@2x.png');\n\x09}\n}\n"))
 	bindata.RegisterFile(filepath.Join("static", "icons.png"), time.Unix(1440397823, 0), []byte("\x89PNG\x0d\n\x1a\n\x00\x00\x00\x0dIHDR\x00\x00\x00\x10\x00\x00\x00\xa0\x08\x03\x00\x00\x002\xe7\x1c&\x00\x00\x00\x09pHYs\x00\x00\x0b\x13\x00\x00\x0b\x13\x01\x00\x9a\x9c\x18\x00\x00\nOiCCPPhotoshop ICC profile\x00\x00x\xda\x9dSgTS\xe9\x16=\xf7\xde\xf4BK\x88\x80\x94KoR\x15\x08 RB\x8b\x80\x14\x91&*!\x09\x10J\x88!\xa1\xd9\x15Q\xc1\x11EE\x04\x1b\xc8\xa0\x88\x03\x8e\x8e\x80\x8c\x15Q,\x0c\x8a\n\xd8\x07\xe4!\xa2\x8e\x83\xa3\x88\x8a\xca\xfb\xe1{\xa3k\xd6\xbc\xf7\xe6\xcd\xfe\xb5\xd7>\xe7\xac\xf3\x9d\xb3\xcf\x07\xc0\x08\x0c\x96H3Q5\x80\x0c\xa9B\x1e\x11\xe0\x83\xc7\xc4\xc6\xe1\xe4.@\x81\n$p\x00\x10\x08\xb3d!s\xfd#\x01\x00\xf8~<<+\"\xc0\x07\xbe\x00\x01x\xd3\x0b\x08\x00\xc0M\x9b\xc00\x1c\x87\xff\x0f\xeaB\x99\\\x01\x80\x84\x01\xc0t\x918K\x08\x80\x14\x00@z\x8eB\xa6\x00@F\x01\x80\x9d\x98&S\x00\xa0\x04\x00`\xcbcb\xe3\x00P-\x00`'\x7f\xe6\xd3\x00\x80\x9d\xf8\x99{\x01\x00[\x94!\x15\x01\xa0\x91\x00 \x13e\x88D\x00h;\x00\xac\xcfV\x8aE\x00X0\x00\x14fK\xc49\x00\xd8-\x000IWfH\x00\xb0\xb7\x00\xc0\xce\x10\x0b\xb2\x00\x08\x0c\x000Q\x88\x85)\x00\x04{\x00`\xc8##x\x00\x84\x99\x00\x14F\xf2W<\xf1+\xae\x10\xe7*\x00\x00x\x99\xb2<\xb9$9E\x81[\x08-q\x07WW.\x1e(\xceI\x17+\x146a\x02a\x9a@.\xc2y\x99\x192\x814\x0f\xe0\xf3\xcc\x00\x00\xa0\x91\x15\x11\xe0\x83\xf3\xfdx\xce\x0e\xae\xce\xce6\x8e\xb6\x0e_-\xea\xbf\x06\xff\"bb\xe3\xfe\xe5\xcf\xabp@\x00\x00\xe1t~\xd1\xfe,/\xb3\x1a\x80;\x06\x80m\xfe\xa2%\xee\x04h^\x0b\xa0u\xf7\x8bf\xb2\x0f@\xb5\x00\xa0\xe9\xdaW\xf3p\xf8~<<E\xa1\x90\xb9\xd9\xd9\xe5\xe4\xe4\xd8J\xc4B[a\xcaW}\xfeg\xc2_\xc0W\xfdl\xf9~<\xfc\xf7\xf5\xe0\xbe\xe2$\x812]\x81G\x04\xf8\xe0\xc2\xcc\xf4L\xa5\x1c\xcf\x92\x09\x84b\xdc\xe6\x8fG\xfc\xb7\x0b\xff\xfc\x1d\xd3\"\xc4Ib\xb9X*\x14\xe3Q\x12q\x8eD\x9a\x8c\xf32\xa5\"\x89B\x92)\xc5%\xd2\xffd\xe2\xdf,\xfb\x03>\xdf5\x00\xb0j>\x01{\x91-\xa8]c\x03\xf6K'\x10Xt\xc0\xe2\xf7\x00\x00\xf2\xbbo\xc1\xd4(\x08\x03\x80h\x83\xe1\xcfw\xff\xef?\xfdG\xa0%\x00\x80fI\x92q\x00\x00^D$.T\xca\xb3?\xc7\x08\x00\x00D\xa0\x81*\xb0A\x1b\xf4\xc1\x18,\xc0\x06\x1c\xc1\x05\xdc\xc1\x0b\xfc`6\x84B$\xc4\xc2B\x10B\nd\x80\x1cr`)\xac\x82B(\x86\xcd\xb0\x1d*`/\xd4@\x1d4\xc0Qh\x86\x93p\x0e.\xc2U\xb8\x0e=p\x0f\xfaa\x08\x9e\xc1(\xbc\x81\x09\x04A\xc8\x08\x13a!\xda\x88\x01b\x8aX#\x8e\x08\x17\x99\x85\xf8!\xc1H\x04\x12\x8b$ \xc9\x88\x14Q\"K\x915H1R\x8aT UH\x1d\xf2=r\x029\x87\\F\xba\x91;\xc8\x002\x82\xfc\x86\xbcG1\x94\x81\xb2Q=\xd4\x0c\xb5C\xb9\xa87\x1a\x84F\xa2\x0b\xd0dt1\x9a\x8f\x16\xa0\x9b\xd0r\xb4\x1a=\x8c6\xa1\xe7\xd0\xabh\x0f\xda\x8f>C\xc70\xc0\xe8\x18\x073\xc4l0.\xc6\xc3B\xb18,\x09\x93c\xcb\xb1\"\xac\x0c\xab\xc6\x1a\xb0V\xac\x03\xbb\x89\xf5c\xcf\xb1w\x04\x12\x81E\xc0\x096\x04wB a\x1eAHXLXN\xd8H\xa8 \x1c$4\x11\xda\x097\x09\x03\x84Q\xc2'\"\x93\xa8K\xb4&\xba\x11\xf9\xc4\x18b21\x87XH,#\xd6\x12\x8f\x13/\x10{\x88C\xc47$\x12\x89C2'\xb9\x90\x02I\xb1\xa4T\xd2\x12\xd2F\xd2nR#\xe9,\xa9\x9b4H\x1a#\x93\xc9\xdadk\xb2\x079\x94, +\xc8\x85\xe4\x9d\xe4\xc3\xe43\xe4\x1b\xe4!\xf2[\n\x9db@q\xa4\xf8S\xe2(R\xcajJ\x19\xe5\x10\xe54\xe5\x06e\x982AU\xa3\x9aR\xdd\xa8\xa1T\x115\x8fZB\xad\xa1\xb6R\xafQ\x87\xa8\x134u\x9a9\xcd\x83\x16IK\xa5\xad\xa2\x95\xd3\x1ah\x17h\xf7i\xaf\xe8t\xba\x11\xdd\x95\x1eN\x97\xd0W\xd2\xcb\xe9G\xe8\x97\xe8\x03\xf4w\x0c\x0d\x86\x15\x83\xc7\x88g(\x19\x9b\x18\x07\x18g\x19w\x18\xaf\x98L\xa6\x19\xd3\x8b\x19\xc7T071\xeb\x98\xe7\x99\x0f\x99oUX*\xb6*|\x15\x91\xca\n\x95J\x95&\x95\x1b*/T\xa9\xaa\xa6\xaa\xde\xaa\x0bU\xf3U\xcbT\x8f\xa9^S}\xaeFU3S\xe3\xa9\x09\xd4\x96\xabU\xaa\x9dP\xebS\x1bSg\xa9;\xa8\x87\xaag\xa8oT?\xa4~Y\xfd\x89\x06Y\xc3L\xc3OC\xa4Q\xa0\xb1_\xe3\xbc\xc6 \x0bc\x19\xb3x,!k\x0d\xab\x86u\x815\xc4&\xb1\xcd\xd9|v*\xbb\x98\xfd\x1d\xbb\x8b=\xaa\xa9\xa19C3J3W\xb3R\xf3\x94f?\x07\xe3\x98q\xf8\x9ctN\x09\xe7(\xa7\x97\xf3~\x8a\xde\x14\xef)\xe2)\x1b\xa64L\xb91e\\k\xaa\x96\x97\x96X\xabH\xabQ\xabG\xeb\xbd6\xae\xed\xa7\x9d\xa6\xbdE\xbbY\xfb\x81\x0eA\xc7J'\\'Gg\x8f\xce\x05\x9d\xe7S\xd9S\xdd\xa7\n\xa7\x16M=:\xf5\xae.\xaak\xa5\x1b\xa1\xbbDw\xbfn\xa7\xee\x98\x9e\xbe^\x80\x9eLo\xa7\xdey\xbd\xe7\xfa\x1c}/\xfdT\xfdm\xfa\xa7\xf5G\x0cX\x06\xb3\x0c$\x06\xdb\x0c\xce\x18<\xc55qo<\x1d/\xc7\xdb\xf1QC]\xc3@C\xa5a\x95a\x97\xe1\x84\x91\xb9\xd1<\xa3\xd5F\x8dF\x0f\x8ci\xc6\\\xe3$\xe3m\xc6m\xc6\xa3&\x06&!&KM\xeaM\xee\x9aRM\xb9\xa6)\xa6;L;L\xc7\xcd\xcc\xcd\xa2\xcd\xd6\x995\x9b=1\xd72\xe7\x9b\xe7\x9b\xd7\x9b\xdf\xb7`ZxZ,\xb6\xa8\xb6\xb8eI\xb2\xe4Z\xa6Y\xee\xb6\xbcn\x85Z9Y\xa5XUZ]\xb3F\xad\x9d\xad%\xd6\xbb\xad\xbb\xa7\x11\xa7\xb9N\x93N\xab\x9e\xd6g\xc3\xb0\xf1\xb6\xc9\xb6\xa9\xb7\x19\xb0\xe5\xd8\x06\xdb\xae\xb6m\xb6}agb\x17g\xb7\xc5\xae\xc3\xee\x93\xbd\x93}\xba}\x8d\xfd=\x07\x0d\x87\xd9\x0e\xab\x1dZ\x1d~s\xb4r\x14:V:\xde\x9a\xce\x9c\xee?}\xc5\xf4\x96\xe9/gX\xcf\x10\xcf\xd83\xe3\xb6\x13\xcb)\xc4i\x9dS\x9b\xd3Gg\x17g\xb9s\x83\xf3\x88\x8b\x89K\x82\xcb.\x97>.\x9b\x1b\xc6\xdd\xc8\xbd\xe4Jt\xf5q]\xe1z\xd2\xf5\x9d\x9b\xb3\x9b\xc2\xed\xa8\xdb\xaf\xee6\xeei\xee\x87\xdc\x9f\xcc4\x9f)\x9eY3s\xd0\xc3\xc8C\xe0Q\xe5\xd1?\x0b\x9f\x950k\xdf\xac~OCO\x81g\xb5\xe7#/c/\x91W\xad\xd7\xb0\xb7\xa5w\xaa\xf7a\xef\x17>\xf6>r\x9f\xe3>\xe3<7\xde2\xdeY_\xcc7\xc0\xb7\xc8\xb7\xcbO\xc3o\x9e_\x85\xdfC\x7f#\xffd\xffz\xff\xd1\x00\xa7\x80%\x01g\x03\x89\x81A\x81[\x02\xfb\xf8z|!\xbf\x8e?:\xdbe\xf6\xb2\xd9\xedA\x8c\xa0\xb9A\x15A\x8f\x82\xad\x82\xe5\xc1\xad!h\xc8\xec\x90\xad!\xf7\xe7\x98\xce\x91\xcei\x0e\x85P~\xe8\xd6\xd0\x07a\xe6a\x8b\xc3~\x0c'\x85\x87\x85W\x86?\x8ep\x88X\x1a\xd11\x975w\xd1\xdcCs\xdfD\xfaD\x96D\xde\x9bg1O9\xaf-J5*>\xaa.j<\xda7\xba4\xba?\xc6.fY\xcc\xd5X\x9dXIlK\x1c9.*\xae6nl\xbe\xdf\xfc\xed\xf3\x87\xe2\x9d\xe2\x0b\xe3{\x17\x98/\xc8]py\xa1\xce\xc2\xf4\x85\xa7\x16\xa9.\x12,:\x96@L\x88N8\x94\xf0A\x10*\xa8\x16\x8c%\xf2\x13w%\x8e\ny\xc2\x1d\xc2g\"/\xd16\xd1\x88\xd8C\\*\x1eN\xf2H*Mz\x92\xec\x91\xbc5y$\xc53\xa5,\xe5\xb9\x84'\xa9\x90\xbcL\x0dL\xdd\x9b:\x9e\x16\x9av m2=:\xbd1\x83\x92\x91\x90qB\xaa!M\x93\xb6g\xeag\xe6fv\xcb\xace\x85\xb2\xfe\xc5n\x8b\xb7/\x1e\x95\x07\xc9k\xb3\x90\xac\x05Y-\n\xb6B\xa6\xe8TZ(\xd7*\x07\xb2geWf\xbf\xcd\x89\xca9\x96\xab\x9e+\xcd\xed\xcc\xb3\xca\xdb\x907\x9c\xef\x9f\xff\xed\x12\xc2\x12\xe1\x92\xb6\xa5\x86KW-\x1dX\xe6\xbd\xacj9\xb2<qy\xdb\n\xe3\x15\x05+\x86V\x06\xac<\xb8\x8a\xb6*m\xd5O\xab\xedW\x97\xae~\xbd&zMk\x81^\xc1\xca\x82\xc1\xb5\x01k\xeb\x0bU\n\xe5\x85}\xeb\xdc\xd7\xed]OX/Y\xdf\xb5a\xfa\x86\x9d\x1b>\x15\x89\x8a\xae\x14\xdb\x17\x97\x15\x7f\xd8(\xdcx\xe5\x1b\x87o\xca\xbf\x99\xdc\x94\xb4\xa9\xab\xc4\xb9d\xcff\xd2f\xe9\xe6\xde-\x9e[\x0e\x96\xaa\x97\xe6\x97\x0en\x0d\xd9\xda\xb4\x0d\xdfV\xb4\xed\xf5\xf6E\xdb/\x97\xcd(\xdb\xbb\x83\xb6C\xb9\xa3\xbf<\xb8\xbce\xa7\xc9\xce\xcd;?T\xa4T\xf4T\xfaT6\xee\xd2\xdd\xb5a\xd7\xf8n\xd1\xee\x1b{\xbc\xf64\xec\xd5\xdb[\xbc\xf7\xfd>\xc9\xbe\xdbU\x01UM\xd5f\xd5e\xfbI\xfb\xb3\xf7?\xae\x89\xaa\xe9\xf8\x96\xfbm]\xadNmq\xed\xc7\x03\xd2\x03\xfd\x07#\x0e\xb6\xd7\xb9\xd4\xd5\x1d\xd2=TR\x8f\xd6+\xebG\x0e\xc7\x1f\xbe\xfe\x9d\xefw-\x0d6\x0dU\x8d\x9c\xc6\xe2#pDy\xe4\xe9\xf7\x09\xdf\xf7\x1e\x0d:\xdav\x8c{\xac\xe1\x07\xd3\x1fv\x1dg\x1d/jB\x9a\xf2\x9aF\x9bS\x9a\xfb[b[\xbaO\xcc>\xd1\xd6\xea\xdez\xfcG\xdb\x1f\x0f\x9c4<YyJ\xf3T\xc9i\xda\xe9\x82\xd3\x93g\xf2\xcf\x8c\x9d\x95\x9d}~.\xf9\xdc`\xdb\xa2\xb6{\xe7c\xce\xdfj\x0fo\xef\xba\x10t\xe1\xd2E\xff\x8b\xe7;\xbc;\xce\\\xf2\xb8t\xf2\xb2\xdb\xe5\x13W\xb8W\x9a\xaf:_m\xeat\xea<\xfe\x93\xd3O\xc7\xbb\x9c\xbb\x9a\xae\xb9\\k\xb9\xeez\xbd\xb5{f\xf7\xe9\x1b\x9e7\xce\xdd\xf4\xbdy\xf1\x16\xff\xd6\xd5\x9e9=\xdd\xbd\xf3zo\xf7\xc5\xf7\xf5\xdf\x16\xdd~r'\xfd\xce\xcb\xbb\xd9w'\xee\xad\xbcO\xbc_\xf4@\xedA\xd9C\xdd\x87\xd5?[\xfe\xdc\xd8\xef\xdc\x7fj\xc0w\xa0\xf3\xd1\xdcG\xf7\x06\x85\x83\xcf\xfe\x91\xf5\x8f\x0fC\x05\x8f\x99\x8f\xcb\x86\x0d\x86\xeb\x9e8>99\xe2?r\xfd\xe9\xfc\xa7C\xcfd\xcf&\x9e\x17\xfe\xa2\xfe\xcb\xae\x17\x16/~\xf8\xd5\xeb\xd7\xce\xd1\x98\xd1\xa1\x97\xf2\x97\x93\xbfm|\xa5\xfd\xea\xc0\xeb\x19\xaf\xdb\xc6\xc2\xc6\x1e\xbe\xc9x31^\xf4V\xfb\xed\xc1w\xdcw\x1d\xef\xa3\xdf\x0fO\xe4| \x7f(\xffh\xf9\xb1\xf5S\xd0\xa7\xfb\x93\x19\x93\x93\xff\x04\x03\x98\xf3\xfcc3-\xdb\x00\x00\x00 cHRM\x00\x00z%\x00\x00\x80\x83\x00\x00\xf9\xff\x00\x00\x80\xe9\x00\x00u0\x00\x00\xea`\x00\x00:\x98\x00\x00\x17o\x92_\xc5F\x00\x00\x02\x97PLTE\x00\x00\x00%%%&&&&'&'('(('((()*)4344434445666566668889::::9:::;;;;;<;<;<;<<<<==<==={xd\x7f\x7f\x7f\xef\xe3\x95\xef\xef\xef\xf0\xef\xef\xfe\xfe\xfe\xff\xff\xff\x00\x00\x00{xd\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\xff\xff\xff\x00\x00\x00\x00\x00\x00{xd\x00\x00\x00\x1bg$!!!\"\"\"\"\"#####$#$$$%%%%%&&&&&'&''''(((('(((()()))*))***+++,++,---,,---/.././/////0000111212222344443444555556566656666777888:9::::;;;<<<==<===>>>>?>????@~AAABBBCCCDDDDDEEDDEDEI\x1a\x06RS\x8bSSSYYY]s\xfccd\x97ll\x9cqqqrs\x9fvvvww\xa3{xd~~~\x7f\x7f\xa8\x86\x86\x86\x8f\x90\xb3\x91\x91\x90\x92\x92\x92\x94\x94\x94\x98\x99\xb9\x99\x99\x99\xa2\xa2\xa2\xa7\xa7\xa7\xaa\xab\xc5\xaf\xaf\xae\xb2\xb2\xb2\xb3\xb3\xb2\xb3\xb3\xb3\xb3\xb3\xc9\xb4\xb4\xb4\xb6\xb7\xcd\xb7\xb7\xb7\xb9\xb9\xb9\xb9\xb9\xcf\xbc\xbc\xbc\xc2\xc2\xc1\xc3\xc3\xc3\xc3\xc3\xd5\xc5\xc5\xc5\xc5\xc5\xd7\xc8\xc8\xd9\xc8\xc9\xda\xca\xca\xca\xcf\xc5\x83\xcf\xc5\x88\xcf\xcf\xcf\xd2\xd2\xd2\xd4\xca\x86\xd4\xd4\xd4\xd5\xd5\xd5\xd6\xd6\xe3\xd7\xd7\xe3\xd8\xd8\xe3\xd9\xce\x87\xd9\xd9\xd9\xdb\xd0\x89\xdc\xdc\xdc\xde\xd3\x8a\xdf\xdf\xdf\xe1\xd6\x8c\xe1\xe1\xe1\xe2\xe3\xe8\xe2\xe3\xe9\xe3\xe3\xe3\xe3\xe3\xea\xe4\xe4\xe4\xe4\xe5\xe4\xe5\xd9\x8f\xe5\xe4\xe4\xe7\xe6\xec\xe7\xe7\xed\xe8\xdc\x90\xe8\xe8\xee\xe9\xe9\xe8\xe9\xe9\xef\xea\xdf\x92\xea\xea\xef\xea\xea\xf0\xeb\xeb\xeb\xeb\xeb\xf1\xed\xe1\x94\xee\xef\xee\xef\xe3\x95\xef\xef\xef\xf0\xf0\xf0\xf0\xff\x00\xf1\xf1\xf1\xf1\xf2\xf2\xf2\xf1\xf1\xf2\xf2\xf1\xf2\xf2\xf2\xf2\xf3\xf3\xf3\xf2\xf2\xf3\xf3\xf3\xf4\xf4\xf4\xf5\xf4\xf5\xf5\xf5\xf4\xf5\xf5\xf5\xf6\xf6\xf5\xf6\xf6\xf6\xf6\xf7\xf7\xf7\xf7\xf6\xf7\xf7\xf7\xf7\xf8\xf7\xf8\xf8\xf8\xf9\xf9\xf9\xf9\xfa\xf9\xfa\xf9\xf9\xfa\xfa\xfa\xfa\xfa\xfb\xfb\xfa\xfa\xfb\xfb\xfb\xfc\xfb\xfb\xfc\xfc\xfc\xfc\xfc\xfd\xfc\xfd\xfd\xfd\xfd\xfc\xfd\xfd\xfd\xfe\xfe\xfe(X\xb2\xfa\x00\x00\x00*tRNS\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0011@JYpp\x8c\x95\xce)v#Y\x00\x00\x03\x91IDATH\xc7\xd5\x96\xf7[\xd3@\x18\xc7O\xdc(\nN\xac#A\xa1Jq\xef\xbap\x0f\xdcZ+\xae\x13\xc5\xbdj\xe2\xde[\xc1\x0d\xee\x89\x03\xf7\xaa[\xb1j5UDk\x83\xd5\x8a\xda\xfe1^.\xe9\x9bk\xc4\xed/~\xda<i>Oz\xcd}\x9f\xf7\xde+\xe2\x0c \x8eK\x10\x08\x09\xf5L \x84\x03yy\x07\x04U\x98L(Y\x10r\x8e\x1d\xcb\x114\x92\x91\xf0 W\xf9\x90\xfb@E \xe2\x1e\x03\x157\x19\xa8\xb8\xc6@\xc5%\x86\xd2\xc5i\x06*\xf2\x18\xa88\xc1@E\x04\x82\xf2\xe8,\xc9dr\xf5#@&\x93\xa8\xa1\xce_\x11!\x8a(6h`\xa2y\x88!\xf7\xf3g\xeegbHl\xa8\xe4\xa1\x08\xc9#I\x12\xb9Q4i\xc2\xa3\xa0\x8dCEa\xe1\x8bB\xc2\xcbPX\x14\xa9\xbc\x02\xf1\xc6\xeb}\xed%\x80\xf0i\x80\x90e\xb9X~W,\x83\xf0\xfb\xdf\x93\x97\xff\x03\x88\x00\xe5c\x00D\x89\x06\x88/\x9f\x83\xc1O\xc1`\x10\x04\xa0\x09\x06u\xfa\xbf\x95\x87&\xf4<4\xa1\xe7\xc1\xe9y\x9c\xdd\xb8\x88\xe4\xc1\x85\xf38\xbf\x10c\xfc\n\xc4\x1b/\xc6\xf3\xd6`/\x08\x9f\x0f\xef\"\x87\x0f\x84,\xe3b\xf9\n\x96A\xf8\xafb\xffa\x8c?\x808\x82q`\xc9\xcc\xb9\x01\x10+f\xce-q\x1eu\x96\x80\xb8\xb5\xe7\xb6\x96\x07g\xcc\x83+%\x0f]\xc0\xf2\x80<\x10\x82\xe9Kt\xfa\xa2Q\xfc(\x8f\xfc\xfc|R\x1fW\xe19\x16\x900\x16\x14\xed\xc0aq\x17+l\xc6\x06\x81\x8db\x9b.\xd41\x02\xbbuQ\xe2t:I5\\\x07\x11\xd4\xf8\xee\xf4\x7f9\x8fF\x04c\x1e\xe1\xd9NR\xf8a\x1e\x19\x19\x19\x9e\x8c\xc8\xfaP\x81\xe7\x98\x96\x9995sj\xd1t\x10^\x0d\x10S\x08>r\xe8\xe5\xa0\x01b\xc2\xc4\x09\x14=\x0fX\x1e\x9a\xb0\x05m\xca\xdb\xf6\xa7y|\xb3^\x98<\xfej\xbd\x84\x87\x85\xe7\x10\x1f\xcdQ`\x04\xb9\x9a1\xc3 Ne\xb1\"%%%\xebTJ\xa4 0\xc2\xf8+\xdf\x04\xa4\xf7\x8f\x9f\xe7\xa1\xf6\xc2_\xc8\xa3\xe0\xe1\x99\xfb\xe7\xc4\x87z\x1e\x05\x05\xfbN\xee\x17].\xe8\xa7.\xd7\xf1\x9d\x87\xd8~\xfa\xd8\xb5|\xd5\xea\x1bO\x9f@\x1fs\xbb\x17\xcb\xdb\xe7\xbb\xdd $I\x9e5k\x8b$\x81\xf0x\xd2}\xe9\xcb<\x9e\x90\xde\x93\xc7\x8f_9\xee%\xf4\xe4;v\xfbd\x82\xdd~'|\xc7\x05\x9bR.\xb6\x0b\xf0\x95\xb7\x97\x87\x8d\x1d;\xec\xe2[\xa6I\x1fLK;\xc84i\xc2\xde\xbd\xa5\xf7S]lu\x18\xf2pl5\xd4\xc7:\x87\xa1>\x1c\xeb\x0d\xf5\xb1\xc1a\xe8\xa7\x8eMt\x7f\x81~Z\xb4\xc9A\xf7\x17\xe8\xa7\xde\xd9\xb3\xd9\xf5B\xb6\x96\xa5K\xe9\xfe\x02\xfdT\xce\xce\xa6\xfb\x0b,\xa0\xf7k\xd7\xd2\xfd\x853\xee/\xff\xa2>\xa8\xa0$&\"\xe8\xa7e\x08\x89\xcd\x9b'\"r\xa6\xa2lT\x14\xdf\xb2C\xfb\x16<\x8a\x8a\xa2\xa2By\xbeU\xe7N\x9d\xac\xadyT\x8e\x8a\x8a|\xbb\xae\x84n]\xdb\xf2\x88\n\xbecjj\x0fJGb\x10\xc7\xb7\xb1Z\xbbX{\xf7\xb6*\xf0\xa4Q#\x85\xee\xfd\xfbuG*\xaa\xe89h`\xaf\xb0\xe0*W\xaa\x14\xddw\xc8\x90>(::\x9a\x0eZ5\xa6J\xcc\x80Q#\x07\xa0\xaa11TT#\xa4\xd9li\x88\x9c\xa9\x88\xab\x1e\x17;\xd8>f(\x8a\xab\x1eKE\xed:5j\x8cHO\x1f\x8ej\xd6\xaeEE\xdd\xf8\xf8\xf8\xd1\x04D\xce\xfa\xf4\xd9\xbf_\xff\x8d0+0\xc2\x9cd\xb1X\xcc\x11w$E\ns\x13KR\xa4h\xd6\xd4\xdc8BX\xcc\x88\xb9\xe6\xbe\x02%\\\"Rt$\x1e\xf5\x00\x00\x00\x00IEND\xaeB`\x82"))
 	bindata.RegisterFile(filepath.Join("static", "icons@2x.png"), time.Unix(1441090962, 0), []byte("\x89PNG\x0d\n\x1a\n\x00\x00\x00\x0dIHDR\x00\x00\x00 \x00\x00\x01@\x08\x03\x00\x00\x00\xea'\x8e(\x00\x00\x00\x09pHYs\x00\x00\x0b\x13\x00\x00\x0b\x13\x01\x00\x9a\x9c\x18\x00\x00\nOiCCPPhotoshop ICC profile\x00\x00x\xda\x9dSgTS\xe9\x16=\xf7\xde\xf4BK\x88\x80\x94KoR\x15\x08 RB\x8b\x80\x14\x91&*!\x09\x10J\x88!\xa1\xd9\x15Q\xc1\x11EE\x04\x1b\xc8\xa0\x88\x03\x8e\x8e\x80\x8c\x15Q,\x0c\x8a\n\xd8\x07\xe4!\xa2\x8e\x83\xa3\x88\x8a\xca\xfb\xe1{\xa3k\xd6\xbc\xf7\xe6\xcd\xfe\xb5\xd7>\xe7\xac\xf3\x9d\xb3\xcf\x07\xc0\x08\x0c\x96H3Q5\x80\x0c\xa9B\x1e\x11\xe0\x83\xc7\xc4\xc6\xe1\xe4.@\x81\n$p\x00\x10\x08\xb3d!s\xfd#\x01\x00\xf8~<<+\"\xc0\x07\xbe\x00\x01x\xd3\x0b\x08\x00\xc0M\x9b\xc00\x1c\x87\xff\x0f\xeaB\x99\\\x01\x80\x84\x01\xc0t\x918K\x08\x80\x14\x00@z\x8eB\xa6\x00@F\x01\x80\x9d\x98&S\x00\xa0\x04\x00`\xcbcb\xe3\x00P-\x00`'\x7f\xe6\xd3\x00\x80\x9d\xf8\x99{\x01\x00[\x94!\x15\x01\xa0\x91\x00 \x13e\x88D\x00h;\x00\xac\xcfV\x8aE\x00X0\x00\x14fK\xc49\x00\xd8-\x000IWfH\x00\xb0\xb7\x00\xc0\xce\x10\x0b\xb2\x00\x08\x0c\x000Q\x88\x85)\x00\x04{\x00`\xc8##x\x00\x84\x99\x00\x14F\xf2W<\xf1+\xae\x10\xe7*\x00\x00x\x99\xb2<\xb9$9E\x81[\x08-q\x07WW.\x1e(\xceI\x17+\x146a\x02a\x9a@.\xc2y\x99\x192\x814\x0f\xe0\xf3\xcc\x00\x00\xa0\x91\x15\x11\xe0\x83\xf3\xfdx\xce\x0e\xae\xce\xce6\x8e\xb6\x0e_-\xea\xbf\x06\xff\"bb\xe3\xfe\xe5\xcf\xabp@\x00\x00\xe1t~\xd1\xfe,/\xb3\x1a\x80;\x06\x80m\xfe\xa2%\xee\x04h^\x0b\xa0u\xf7\x8bf\xb2\x0f@\xb5\x00\xa0\xe9\xdaW\xf3p\xf8~<<E\xa1\x90\xb9\xd9\xd9\xe5\xe4\xe4\xd8J\xc4B[a\xcaW}\xfeg\xc2_\xc0W\xfdl\xf9~<\xfc\xf7\xf5\xe0\xbe\xe2$\x812]\x81G\x04\xf8\xe0\xc2\xcc\xf4L\xa5\x1c\xcf\x92\x09\x84b\xdc\xe6\x8fG\xfc\xb7\x0b\xff\xfc\x1d\xd3\"\xc4Ib\xb9X*\x14\xe3Q\x12q\x8eD\x9a\x8c\xf32\xa5\"\x89B\x92)\xc5%\xd2\xffd\xe2\xdf,\xfb\x03>\xdf5\x00\xb0j>\x01{\x91-\xa8]c\x03\xf6K'\x10Xt\xc0\xe2\xf7\x00\x00\xf2\xbbo\xc1\xd4(\x08\x03\x80h\x83\xe1\xcfw\xff\xef?\xfdG\xa0%\x00\x80fI\x92q\x00\x00^D$.T\xca\xb3?\xc7\x08\x00\x00D\xa0\x81*\xb0A\x1b\xf4\xc1\x18,\xc0\x06\x1c\xc1\x05\xdc\xc1\x0b\xfc`6\x84B$\xc4\xc2B\x10B\nd\x80\x1cr`)\xac\x82B(\x86\xcd\xb0\x1d*`/\xd4@\x1d4\xc0Qh\x86\x93p\x0e.\xc2U\xb8\x0e=p\x0f\xfaa\x08\x9e\xc1(\xbc\x81\x09\x04A\xc8\x08\x13a!\xda\x88\x01b\x8aX#\x8e\x08\x17\x99\x85\xf8!\xc1H\x04\x12\x8b$ \xc9\x88\x14Q\"K\x915H1R\x8aT UH\x1d\xf2=r\x029\x87\\F\xba\x91;\xc8\x002\x82\xfc\x86\xbcG1\x94\x81\xb2Q=\xd4\x0c\xb5C\xb9\xa87\x1a\x84F\xa2\x0b\xd0dt1\x9a\x8f\x16\xa0\x9b\xd0r\xb4\x1a=\x8c6\xa1\xe7\xd0\xabh\x0f\xda\x8f>C\xc70\xc0\xe8\x18\x073\xc4l0.\xc6\xc3B\xb18,\x09\x93c\xcb\xb1\"\xac\x0c\xab\xc6\x1a\xb0V\xac\x03\xbb\x89\xf5c\xcf\xb1w\x04\x12\x81E\xc0\x096\x04wB a\x1eAHXLXN\xd8H\xa8 \x1c$4\x11\xda\x097\x09\x03\x84Q\xc2'\"\x93\xa8K\xb4&\xba\x11\xf9\xc4\x18b21\x87XH,#\xd6\x12\x8f\x13/\x10{\x88C\xc47$\x12\x89C2'\xb9\x90\x02I\xb1\xa4T\xd2\x12\xd2F\xd2nR#\xe9,\xa9\x9b4H\x1a#\x93\xc9\xdadk\xb2\x079\x94, +\xc8\x85\xe4\x9d\xe4\xc3\xe43\xe4\x1b\xe4!\xf2[\n\x9db@q\xa4\xf8S\xe2(R\xcajJ\x19\xe5\x10\xe54\xe5\x06e\x982AU\xa3\x9aR\xdd\xa8\xa1T\x115\x8fZB\xad\xa1\xb6R\xafQ\x87\xa8\x134u\x9a9\xcd\x83\x16IK\xa5\xad\xa2\x95\xd3\x1ah\x17h\xf7i\xaf\xe8t\xba\x11\xdd\x95\x1eN\x97\xd0W\xd2\xcb\xe9G\xe8\x97\xe8\x03\xf4w\x0c\x0d\x86\x15\x83\xc7\x88g(\x19\x9b\x18\x07\x18g\x19w\x18\xaf\x98L\xa6\x19\xd3\x8b\x19\xc7T071\xeb\x98\xe7\x99\x0f\x99oUX*\xb6*|\x15\x91\xca\n\x95J\x95&\x95\x1b*/T\xa9\xaa\xa6\xaa\xde\xaa\x0bU\xf3U\xcbT\x8f\xa9^S}\xaeFU3S\xe3\xa9\x09\xd4\x96\xabU\xaa\x9dP\xebS\x1bSg\xa9;\xa8\x87\xaag\xa8oT?\xa4~Y\xfd\x89\x06Y\xc3L\xc3OC\xa4Q\xa0\xb1_\xe3\xbc\xc6 \x0bc\x19\xb3x,!k\x0d\xab\x86u\x815\xc4&\xb1\xcd\xd9|v*\xbb\x98\xfd\x1d\xbb\x8b=\xaa\xa9\xa19C3J3W\xb3R\xf3\x94f?\x07\xe3\x98q\xf8\x9ctN\x09\xe7(\xa7\x97\xf3~\x8a\xde\x14\xef)\xe2)\x1b\xa64L\xb91e\\k\xaa\x96\x97\x96X\xabH\xabQ\xabG\xeb\xbd6\xae\xed\xa7\x9d\xa6\xbdE\xbbY\xfb\x81\x0eA\xc7J'\\'Gg\x8f\xce\x05\x9d\xe7S\xd9S\xdd\xa7\n\xa7\x16M=:\xf5\xae.\xaak\xa5\x1b\xa1\xbbDw\xbfn\xa7\xee\x98\x9e\xbe^\x80\x9eLo\xa7\xdey\xbd\xe7\xfa\x1c}/\xfdT\xfdm\xfa\xa7\xf5G\x0cX\x06\xb3\x0c$\x06\xdb\x0c\xce\x18<\xc55qo<\x1d/\xc7\xdb\xf1QC]\xc3@C\xa5a\x95a\x97\xe1\x84\x91\xb9\xd1<\xa3\xd5F\x8dF\x0f\x8ci\xc6\\\xe3$\xe3m\xc6m\xc6\xa3&\x06&!&KM\xeaM\xee\x9aRM\xb9\xa6)\xa6;L;L\xc7\xcd\xcc\xcd\xa2\xcd\xd6\x995\x9b=1\xd72\xe7\x9b\xe7\x9b\xd7\x9b\xdf\xb7`ZxZ,\xb6\xa8\xb6\xb8eI\xb2\xe4Z\xa6Y\xee\xb6\xbcn\x85Z9Y\xa5XUZ]\xb3F\xad\x9d\xad%\xd6\xbb\xad\xbb\xa7\x11\xa7\xb9N\x93N\xab\x9e\xd6g\xc3\xb0\xf1\xb6\xc9\xb6\xa9\xb7\x19\xb0\xe5\xd8\x06\xdb\xae\xb6m\xb6}agb\x17g\xb7\xc5\xae\xc3\xee\x93\xbd\x93}\xba}\x8d\xfd=\x07\x0d\x87\xd9\x0e\xab\x1dZ\x1d~s\xb4r\x14:V:\xde\x9a\xce\x9c\xee?}\xc5\xf4\x96\xe9/gX\xcf\x10\xcf\xd83\xe3\xb6\x13\xcb)\xc4i\x9dS\x9b\xd3Gg\x17g\xb9s\x83\xf3\x88\x8b\x89K\x82\xcb.\x97>.\x9b\x1b\xc6\xdd\xc8\xbd\xe4Jt\xf5q]\xe1z\xd2\xf5\x9d\x9b\xb3\x9b\xc2\xed\xa8\xdb\xaf\xee6\xeei\xee\x87\xdc\x9f\xcc4\x9f)\x9eY3s\xd0\xc3\xc8C\xe0Q\xe5\xd1?\x0b\x9f\x950k\xdf\xac~OCO\x81g\xb5\xe7#/c/\x91W\xad\xd7\xb0\xb7\xa5w\xaa\xf7a\xef\x17>\xf6>r\x9f\xe3>\xe3<7\xde2\xdeY_\xcc7\xc0\xb7\xc8\xb7\xcbO\xc3o\x9e_\x85\xdfC\x7f#\xffd\xffz\xff\xd1\x00\xa7\x80%\x01g\x03\x89\x81A\x81[\x02\xfb\xf8z|!\xbf\x8e?:\xdbe\xf6\xb2\xd9\xedA\x8c\xa0\xb9A\x15A\x8f\x82\xad\x82\xe5\xc1\xad!h\xc8\xec\x90\xad!\xf7\xe7\x98\xce\x91\xcei\x0e\x85P~\xe8\xd6\xd0\x07a\xe6a\x8b\xc3~\x0c'\x85\x87\x85W\x86?\x8ep\x88X\x1a\xd11\x975w\xd1\xdcCs\xdfD\xfaD\x96D\xde\x9bg1O9\xaf-J5*>\xaa.j<\xda7\xba4\xba?\xc6.fY\xcc\xd5X\x9dXIlK\x1c9.*\xae6nl\xbe\xdf\xfc\xed\xf3\x87\xe2\x9d\xe2\x0b\xe3{\x17\x98/\xc8]py\xa1\xce\xc2\xf4\x85\xa7\x16\xa9.\x12,:\x96@L\x88N8\x94\xf0A\x10*\xa8\x16\x8c%\xf2\x13w%\x8e\ny\xc2\x1d\xc2g\"/\xd16\xd1\x88\xd8C\\*\x1eN\xf2H*Mz\x92\xec\x91\xbc5y$\xc53\xa5,\xe5\xb9\x84'\xa9\x90\xbcL\x0dL\xdd\x9b:\x9e\x16\x9av m2=:\xbd1\x83\x92\x91\x90qB\xaa!M\x93\xb6g\xeag\xe6fv\xcb\xace\x85\xb2\xfe\xc5n\x8b\xb7/\x1e\x95\x07\xc9k\xb3\x90\xac\x05Y-\n\xb6B\xa6\xe8TZ(\xd7*\x07\xb2geWf\xbf\xcd\x89\xca9\x96\xab\x9e+\xcd\xed\xcc\xb3\xca\xdb\x907\x9c\xef\x9f\xff\xed\x12\xc2\x12\xe1\x92\xb6\xa5\x86KW-\x1dX\xe6\xbd\xacj9\xb2<qy\xdb\n\xe3\x15\x05+\x86V\x06\xac<\xb8\x8a\xb6*m\xd5O\xab\xedW\x97\xae~\xbd&zMk\x81^\xc1\xca\x82\xc1\xb5\x01k\xeb\x0bU\n\xe5\x85}\xeb\xdc\xd7\xed]OX/Y\xdf\xb5a\xfa\x86\x9d\x1b>\x15\x89\x8a\xae\x14\xdb\x17\x97\x15\x7f\xd8(\xdcx\xe5\x1b\x87o\xca\xbf\x99\xdc\x94\xb4\xa9\xab\xc4\xb9d\xcff\xd2f\xe9\xe6\xde-\x9e[\x0e\x96\xaa\x97\xe6\x97\x0en\x0d\xd9\xda\xb4\x0d\xdfV\xb4\xed\xf5\xf6E\xdb/\x97\xcd(\xdb\xbb\x83\xb6C\xb9\xa3\xbf<\xb8\xbce\xa7\xc9\xce\xcd;?T\xa4T\xf4T\xfaT6\xee\xd2\xdd\xb5a\xd7\xf8n\xd1\xee\x1b{\xbc\xf64\xec\xd5\xdb[\xbc\xf7\xfd>\xc9\xbe\xdbU\x01UM\xd5f\xd5e\xfbI\xfb\xb3\xf7?\xae\x89\xaa\xe9\xf8\x96\xfbm]\xadNmq\xed\xc7\x03\xd2\x03\xfd\x07#\x0e\xb6\xd7\xb9\xd4\xd5\x1d\xd2=TR\x8f\xd6+\xebG\x0e\xc7\x1f\xbe\xfe\x9d\xefw-\x0d6\x0dU\x8d\x9c\xc6\xe2#pDy\xe4\xe9\xf7\x09\xdf\xf7\x1e\x0d:\xdav\x8c{\xac\xe1\x07\xd3\x1fv\x1dg\x1d/jB\x9a\xf2\x9aF\x9bS\x9a\xfb[b[\xbaO\xcc>\xd1\xd6\xea\xdez\xfcG\xdb\x1f\x0f\x9c4<YyJ\xf3T\xc9i\xda\xe9\x82\xd3\x93g\xf2\xcf\x8c\x9d\x95\x9d}~.\xf9\xdc`\xdb\xa2\xb6{\xe7c\xce\xdfj\x0fo\xef\xba\x10t\xe1\xd2E\xff\x8b\xe7;\xbc;\xce\\\xf2\xb8t\xf2\xb2\xdb\xe5\x13W\xb8W\x9a\xaf:_m\xeat\xea<\xfe\x93\xd3O\xc7\xbb\x9c\xbb\x9a\xae\xb9\\k\xb9\xeez\xbd\xb5{f\xf7\xe9\x1b\x9e7\xce\xdd\xf4\xbdy\xf1\x16\xff\xd6\xd5\x9e9=\xdd\xbd\xf3zo\xf7\xc5\xf7\xf5\xdf\x16\xdd~r'\xfd\xce\xcb\xbb\xd9w'\xee\xad\xbcO\xbc_\xf4@\xedA\xd9C\xdd\x87\xd5?[\xfe\xdc\xd8\xef\xdc\x7fj\xc0w\xa0\xf3\xd1\xdcG\xf7\x06\x85\x83\xcf\xfe\x91\xf5\x8f\x0fC\x05\x8f\x99\x8f\xcb\x86\x0d\x86\xeb\x9e8>99\xe2?r\xfd\xe9\xfc\xa7C\xcfd\xcf&\x9e\x17\xfe\xa2\xfe\xcb\xae\x17\x16/~\xf8\xd5\xeb\xd7\xce\xd1\x98\xd1\xa1\x97\xf2\x97\x93\xbfm|\xa5\xfd\xea\xc0\xeb\x19\xaf\xdb\xc6\xc2\xc6\x1e\xbe\xc9x31^\xf4V\xfb\xed\xc1w\xdcw\x1d\xef\xa3\xdf\x0fO\xe4| \x7f(\xffh\xf9\xb1\xf5S\xd0\xa7\xfb\x93\x19\x93\x93\xff\x04\x03\x98\xf3\xfcc3-\xdb\x00\x00A%iTXtXML:com.adobe.xmp\x00\x00\x00\x00\x00<?xpacket begin=\"\xef\xbb\xbf\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n<x:xmpmeta xmlns:x=\"adobe:ns:meta/\" x:xmptk=\"Adobe XMP Core 5.5-c014 79.151481, 2013/03/13-12:09:15        \">\n   <rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\">\n      <rdf:Description rdf:about=\"\"\n            xmlns:xmp=\"http://ns.adobe.com/xap/1.0/\"\n            xmlns:dc=\"http://purl.org/dc/elements/1.1/\"\n            xmlns:photoshop=\"http://ns.adobe.com/photoshop/1.0/\"\n            xmlns:xmpMM=\"http://ns.adobe.com/xap/1.0/mm/\"\n            xmlns:stEvt=\"http://ns.adobe.com/xap/1.0/sType/ResourceEvent#\"\n            xmlns:stRef=\"http://ns.adobe.com/xap/1.0/sType/ResourceRef#\"\n            xmlns:tiff=\"http://ns.adobe.com/tiff/1.0/\"\n            xmlns:exif=\"http://ns.adobe.com/exif/1.0/\">\n         <xmp:CreatorTool>Adobe Photoshop CC (Windows)</xmp:CreatorTool>\n         <xmp:CreateDate>2015-08-23T19:57:39-07:00</xmp:CreateDate>\n         <xmp:ModifyDate>2015-09-01T00:01:28-07:00</xmp:ModifyDate>\n         <xmp:MetadataDate>2015-09-01T00:01:28-07:00</xmp:MetadataDate>\n         <dc:format>image/png</dc:format>\n         <photoshop:ColorMode>3</photoshop:ColorMode>\n         <photoshop:ICCProfile>sRGB IEC61966-2.1</photoshop:ICCProfile>\n         <xmpMM:InstanceID>xmp.iid:95124488-de79-a24e-ab73-de95d0cc4406</xmpMM:InstanceID>\n         <xmpMM:DocumentID>xmp.did:233bf3cd-e247-834f-ac41-dbf5f267eff5</xmpMM:DocumentID>\n         <xmpMM:OriginalDocumentID>xmp.did:233bf3cd-e247-834f-ac41-dbf5f267eff5</xmpMM:OriginalDocumentID>\n         <xmpMM:History>\n            <rdf:Seq>\n               <rdf:li rdf:parseType=\"Resource\">\n                  <stEvt:action>created</stEvt:action>\n                  <stEvt:instanceID>xmp.iid:233bf3cd-e247-834f-ac41-dbf5f267eff5</stEvt:instanceID>\n                  <stEvt:when>2015-08-23T19:57:39-07:00</stEvt:when>\n                  <stEvt:softwareAgent>Adobe Photoshop CC (Windows)</stEvt:softwareAgent>\n               </rdf:li>\n               <rdf:li rdf:parseType=\"Resource\">\n                  <stEvt:action>saved</stEvt:action>\n                  <stEvt:instanceID>xmp.iid:885c13d1-02ff-0041-a68a-19ba91c23f2e</stEvt:instanceID>\n                  <stEvt:when>2015-08-23T23:18:13-07:00</stEvt:when>\n                  <stEvt:softwareAgent>Adobe Photoshop CC (Windows)</stEvt:softwareAgent>\n                  <stEvt:changed>/</stEvt:changed>\n               </rdf:li>\n               <rdf:li rdf:parseType=\"Resource\">\n                  <stEvt:action>saved</stEvt:action>\n                  <stEvt:instanceID>xmp.iid:ddfafded-00df-1e4d-9f47-00d11aa3fb80</stEvt:instanceID>\n                  <stEvt:when>2015-09-01T00:01:28-07:00</stEvt:when>\n                  <stEvt:softwareAgent>Adobe Photoshop CC (Windows)</stEvt:softwareAgent>\n                  <stEvt:changed>/</stEvt:changed>\n               </rdf:li>\n               <rdf:li rdf:parseType=\"Resource\">\n                  <stEvt:action>converted</stEvt:action>\n                  <stEvt:parameters>from application/vnd.adobe.photoshop to image/png</stEvt:parameters>\n               </rdf:li>\n               <rdf:li rdf:parseType=\"Resource\">\n                  <stEvt:action>derived</stEvt:action>\n                  <stEvt:parameters>converted from application/vnd.adobe.photoshop to image/png</stEvt:parameters>\n               </rdf:li>\n               <rdf:li rdf:parseType=\"Resource\">\n                  <stEvt:action>saved</stEvt:action>\n                  <stEvt:instanceID>xmp.iid:95124488-de79-a24e-ab73-de95d0cc4406</stEvt:instanceID>\n                  <stEvt:when>2015-09-01T00:01:28-07:00</stEvt:when>\n                  <stEvt:softwareAgent>Adobe Photoshop CC (Windows)</stEvt:softwareAgent>\n                  <stEvt:changed>/</stEvt:changed>\n               </rdf:li>\n            </rdf:Seq>\n         </xmpMM:History>\n         <xmpMM:DerivedFrom rdf:parseType=\"Resource\">\n            <stRef:instanceID>xmp.iid:ddfafded-00df-1e4d-9f47-00d11aa3fb80</stRef:instanceID>\n            <stRef:documentID>xmp.did:233bf3cd-e247-834f-ac41-dbf5f267eff5</stRef:documentID>\n            <stRef:originalDocumentID>xmp.did:233bf3cd-e247-834f-ac41-dbf5f267eff5</stRef:originalDocumentID>\n         </xmpMM:DerivedFrom>\n         <tiff:Orientation>1</tiff:Orientation>\n         <tiff:XResolution>720000/10000</tiff:XResolution>\n         <tiff:YResolution>720000/10000</tiff:YResolution>\n         <tiff:ResolutionUnit>2</tiff:ResolutionUnit>\n         <exif:ColorSpace>1</exif:ColorSpace>\n         <exif:PixelXDimension>32</exif:PixelXDimension>\n         <exif:PixelYDimension>320</exif:PixelYDimension>\n      </rdf:Description>\n   </rdf:RDF>\n</x:xmpmeta>\n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                                                                                                    \n                            \n<?xpacket end=\"w\"?>\x9cd#<\x00\x00\x00 cHRM\x00\x00z%\x00\x00\x80\x83\x00\x00\xf9\xff\x00\x00\x80\xe9\x00\x00u0\x00\x00\xea`\x00\x00:\x98\x00\x00\x17o\x92_\xc5F\x00\x00\x02\x9aPLTE\x00\x00\x00$$$%%%&&&&'&'('4344434445666566668889::::9:::;;;;;<;<;<;<<<<==<==={xd~~~\x7f\x7f\x7f\xef\xe3\x95\xef\xef\xef\xf0\xef\xef\xfe\xfe\xfe\xff\xff\xff\xff\xff\xff\x9e\x9e\x9e{xdiii\x00\x00\x00\x1bg$!!!\"\"\"\"\"#####$#$$$%%%%%&&&&&'&''''(((('(((()()))*))***+++,++,---,,---/.././/////0000111212222344443444555556566656666777888:9::::;;;<<<==<===>>>>?>????@~@@@AAABBBCCCDDDDDEEDDEDEI\x1a\x06RS\x8bSSSYYY]s\xfccd\x97mm\x9cqqqrs\x9fvvvww\xa3{xd~~~\x7f\x7f\xa8\x86\x86\x86\x88\x88\x88\x8f\x90\xb3\x91\x91\x90\x92\x92\x92\x94\x94\x94\x99\x99\x99\xa2\xa2\xa2\xa4\xa3\xa3\xa7\xa7\xa7\xb1\xb1\xc9\xb2\xb2\xb2\xb3\xb3\xb2\xb3\xb3\xb3\xb3\xb3\xc9\xb4\xb4\xb4\xb6\xb7\xcd\xb7\xb7\xb7\xb9\xb9\xb9\xb9\xb9\xcf\xbb\xbb\xcf\xbc\xbc\xbc\xc2\xc2\xc1\xc3\xc3\xc3\xc5\xc5\xc5\xc5\xc5\xd7\xca\xca\xca\xcf\xc5\x83\xcf\xc5\x88\xcf\xcf\xcf\xd1\xd1\xd1\xd2\xd1\xd1\xd2\xd2\xd2\xd4\xca\x86\xd4\xd4\xd4\xd5\xd5\xd5\xd9\xce\x87\xd9\xd9\xd9\xdc\xd1\x89\xdc\xdc\xdc\xdd\xd1\x8a\xde\xd2\x8b\xde\xd3\x8a\xdf\xd3\x8b\xdf\xdf\xdf\xe0\xd5\x8c\xe1\xd6\x8d\xe1\xe1\xe1\xe3\xd7\x8e\xe3\xe3\xe3\xe3\xe3\xea\xe4\xd8\x8e\xe4\xe4\xe4\xe4\xe5\xe4\xe5\xda\x8f\xe5\xe4\xe4\xe7\xdb\x90\xe7\xe7\xed\xe8\xdc\x91\xe8\xe8\xee\xe9\xde\x92\xe9\xe9\xe8\xea\xdf\x92\xeb\xe0\x93\xeb\xeb\xeb\xeb\xeb\xf1\xec\xe0\x94\xed\xe1\x94\xee\xef\xee\xef\xe3\x95\xef\xef\xef\xf0\xf0\xf0\xf0\xff\x00\xf1\xf1\xf1\xf1\xf2\xf2\xf2\xf1\xf1\xf2\xf2\xf1\xf2\xf2\xf2\xf2\xf3\xf3\xf3\xf2\xf2\xf3\xf3\xf3\xf3\xf4\xf4\xf4\xf4\xf4\xf4\xf5\xf5\xf5\xf4\xf5\xf5\xf5\xf4\xf5\xf5\xf5\xf5\xf6\xf6\xf6\xf6\xf5\xf6\xf6\xf6\xf6\xf7\xf7\xf7\xf7\xf6\xf7\xf7\xf7\xf7\xf8\xf7\xf7\xf8\xf8\xf8\xf8\xf8\xf8\xf8\xf9\xf8\xf9\xf9\xf9\xf9\xf9\xf9\xfa\xf9\xfa\xf9\xf9\xfa\xfa\xfa\xfa\xfa\xfb\xfb\xfa\xfa\xfb\xfb\xfb\xfc\xfb\xfb\xfc\xfc\xfc\xfc\xfc\xfd\xfc\xfd\xfd\xfd\xfd\xfc\xfd\xfd\xfd\xfe\xfe\xfed\xe1\x80\xb0\x00\x00\x00#tRNS\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb3\xca\xce\xd9\x81\x07\x011\x00\x00\x06:IDATh\xde\xed\x9aMlTU\x14\xc7\x7f\xf7\xbe\xf7\xe6\xa3-\x85\xa9|X\x82\xd3\x18Q)\x0b\xab\x1b\x12\x17\xc4\xb0!\xa5I\x11\xd3\x96\xb0k\xc2\xc2\x18\x8b\xc411\xd1\x84h0\x8d\x89\x04-\x1a\x16\x86\xb8!\xba\x14D\x88\xc8\xc2\x18\xc2\xc6\x84\x9dn\xb41\x16\xda\x8aI#\xb4\xd6\x01K\xe7\xeb\xba\x98\xf7\xde\xbcy_3o^)b\xb8\x8by\x93{\xce\xfb\xdfs\xcf;\xe7\x7f\xcf;3\"K\xf8\x90\x0d\xe4\xe8\xe6u\x10\x80\x0b\xb0\x8c\xc4\xf0\"\x0c\xd6]\x9cCd\xab\x82\xa7\x13@a\x92\x0bN\x84\xa2\x850\xc8\xd6\xea\xccV/\x86\xc8j\x03@6\x0d\xc0\xd2\x8cK|\xb1\xacu\x0d\x00,\xb6\x97J\xa5R\xc9-\xe7\xc9\xdf\xc4\x18\xfd\xfc<m\xcf\xf4\xf4\xd6)\\\xaa\xda\xd0\xd5c\xcb\xbb|\xfd\xb0\x01{~C\xc5\xe5\x03\x1d@\xad\xb7\xe7\x94/B9\xc0\xcd\x9a\x8d\xd0\xe8YT\xfe\x03\n\xe5\xd8\n\x0d\x97X\x05?\xc4_\xa2'Nf\x89\xc7\x07B\xe5\x17e\xf9b\xa8\xfc\x1f\x91]&Y\nT(\xa3\x93\x0cC\xd0\xd0\x8b\xc0\x90k\xfa\x8c#\xbf}w1\xa4<\xfc\x90s\x88'`\xf8+\xa0\x82\x11\xec\x87\x97\xec\x10\xd1\xad\xd5\xae9\xc4\xb9\x09\x86\xcfa\xd4\xf8\xc13r\xb0\xaf\xe42R\xb3\x87\xa91\\,\x86=\x8b\x9c\xb5\x17\xddO:Q\xbd\x0c\x9f\xc1\xa8)\xc8\x06<\xe9\x18#\x00l1q\xf4\x10\xcdhT\x8c\xd6HA\xc6V\x10\xf7\x1eA\xbbg~h\x1eA\xb6\x8a\xd0\xfc\x12\xf1\x1f\xb7\x88\x890\xe1+4\x9aB(\xfaL\xde/~\x98B\xa2P\x08\x07?\x10\x99\x1f\xa4\xd44)[\xe5\x07\xe9\xd0\xb6\xf8\xe1|\xe4x\xa8 \xa9\xc8\x03|\xff\xe7~6\xbb\xf9\xa1\xb6\xd4\xa5_\x03\xc3\xbeb\xc0\xe7\xb7\xea\x93\xa0\x0e\xc1\x98\xbcz+$\xec\x05\x1f\x02\xac\xed\x1a9\xe6\xb8\xcfm\xc3\xda\xae\x91\xd0\xa8~vw\xc1\x95\x88.?\xec&\x11\x9e\xbc:\x8aJ\xc0.4\xab\x8c\x90\x81\x08ZU8n\xc6sPL\x8e\x07\xdap\xb4\xb6v\xa7\xf0*\x94\xc7\xcd\x93\xb4{\x11=\xe7\x83`\x00tJ\xc9+\x01\xb9Yy\xb7\xad\xad3W\xb1\x87\x07!\xc9\x91H\xd9\xad\x1a)\x88\x95\xe1\x87h\x1c\xe5\x1c\xe7\x1b!\xec-4\xe4\x87\x93@6\xcc\x86C\x8fzl\x98v\xd5\x0f'Cw\x91\xaba\xc8\xc0\xeco\x89\x1f\x88\xcd\x0f\xd5q\x00\x80\xee ~\xa8\x8eY\xba=7~\xb1\x00@f\x14\xca\xb3\xe7\xd8\xe1\xb6\xcd\x94\xb3p\x1af\xcfym\xf8i\xc1\x9aY\xe0\xdb\xc9\xf0\xa7Y'\xf7S\x98\x8c}\xb0no\xa40\xb2\xddO\xe1\x99\x8c5\x93ad\x9f\x1f\xc2aS#s\x18\xfa\xf6\xf9\xf1\xe4\xeb\x8e\xdb\x9e\x93\x14\xc3\xcf\xf4\xbe\xc6\xa7\xbfX\xbd\xd3\x7f\xc5j\x98\x95\xe6\x87\xaf\x1d;\xf3Ex\xb1\xd8\x14?t\x87\xd5\x0f>\xfc\xf0\xb6-\xdch\xf2\x83\xd1\x1c?\xd8\x085\x92?kr\xcc\x99\xd0:\xca\xcb\x0f\x9f\x01\xf02\xa7\xe8j\x91\x1f^u]-~h\x1e\xe1\x14\nP\x08\x14c|\xca;\x91\x9f\xe6\xa1\xba\xe9\xb1\x16\xe2\xe1c\xf3\xfa\x06\x1f\x01\xf0^d\x84\xb7\xf0~\x8b\x86p\x14Q\xd7\x03\xf8 2\xc2x\xec\xbcx\x93\x09r`~\xc2\x89\xc8\x08'\xec\xbbN<\x80\xfc\x10\xff\xfd\xc2\x88\xfd~\xc1\xff\xea\xfd\xe2K\xcf\x1a\xee\xfaa\xc0\xe4\xaf\xeaH\xb5\x16\x0fg\x01J\xa5@\x85\xaa\x1cJ\xfe\xc7Au\xfd\x12@I\x0f\x0c\x98R\xdd\xc5\xe7@\xd1\xab\xb5\xcdU?\x85\xcb\x00\xec`\x8eM\\\xa6?d\x9b\xf9FA\xbb&\xf4P;\x16\xe3X\xec\x8f\xc4\xb4\xad5I\x14\x12(\xbb\xda%\xd1\x9b$\xfa\x8a\xf2C\xb1\xe5\xfa\xc1\xe2\x07c\x95\xfa\x0f\xd6\x97_P\xcc\xb1\x81[-\xf3\x03\x081??;\xbb\xb0P\xe3\x87b\xb1X\x8c\xc6\x0f)\x16iw\xf5'\xa3\xd4\x93\x8a\xebH\x16y\x81\xb5(\xb6F\xae\x1f4\xa0\xc8A\xbe\xe1;\x86\x1d\xf5i4\x84\x83|\x02<V\x17\x95\xd1b\xf2(G\x18g]\xdd]\xd1\xde\xb3$\xef\xf3\x04S-\xd9\xf0\xe3i\x806\xe0\x06I\x8e\x03\x8c\xd6!\xf4\x8dB:\xad\xcc\x91N\xa7\x19\xed\xab_\xa2o\x88;E\xbb3pgi\xa8\xcfmC\xef\x10\x05K\xbe\xc4P\xaf\xd7\xc8\xde\x032_]!/\xf7\xf7\xfa\xed\xe2\xa9\xfd\xdc.\x97\xcb\xe5\xdb\x0co\xf3\xd9\xa6\x94r\xdb\x9eT>\x9f\xcf\xa7\xf6l\x97R\xfa\xfaa\xe7.\x80];}=\xb9\x0e`\xf0\xee\x0f<?\x18\x96\xbc#VG\xbc\x96\x0cY`o\x80\x9b\xa3E\xb5\x19W\x999\xb4X\xfcp|n\xd3B9\x1a?\xd8\nSS\xd7\xaf_\xbb\x96X7W\xa8\xe6\xb6\x10\x86\xd1J\xfdP\xf8+\x93\x88W?$:\xe6\xd1b\xf5'\x0b\xf3\xeb\xff\x1e\x01\x94\xdd\x9f\x8c\x96\xdd\x1a\x89\xce\x9bh\xce~\x95\x1b\xa1\xf0G!\xac?\x09\x05(D<\xb3l\x05]\xd74\xedJ\"\x91H\\\xb1\x7f\x0f\xf2CH\xa5\xc2\xfb\x93<\xc2\x0da(\xc7.\xdcG\xd2\x8d@\x1b4M\xd3\xf4~\x80\xddBJ\xc3\x08\xb0a\xcc\xf9\x06\xe5k\xc3k\x16o\xb7~v\xbb{\x09\x0f~\xfd\xd0\x14\x82\xf0\xfe\xffa\x86l\x88B\x92e\x1f\x1b~\x07\xb6\x98r\x92\xcb\xcc\x98+o\xf1\xd8\x90\xac~d\xdd\x08\x92\xcd\x00\xa4!\xa9\n*u7;c\xfb\xb3\x8aP\x93C\x02R\x0e\x0c\x87\x91m\x90V\x80\\\x82;0c\xd0]gC\x1bT\xff%A\x1a\xda\xad\x1ej\xcd\xf5\x9dv\xc1/h_bM\xde\xeciI\x8f\xdc\xc4XcB\x986d ]\xf7\xcb\xf9]X`\x86\xac\x89\x90\xb1\xd7w\xbc_d\xc8Z\x08\x1d\xa4\xccXN\x03K\xb6\xd6M\xd3\xa7\x1dAO\xb2c\x16t\x98\xb1\xd3`\x07:\n\xdd\xae\xfdE\xb3\x11\xa5j\x91\xad\xa1\x1cQ\xa9\x9aD\xc82g\xea\xeaf-d9mc\xd3Q\xad!\xa8 \xd1H\xa0\xccZ8ZoP\xdaLk}\x93\x113K\xa3\x82D!\x90(\x13\xabbZ\xd1\x1c\x82\x8e\xa0\x840Oji\xfaDE\xda\x85 \x01h\x14\xcd)#Vv\xafz\x8f\xf4\xa1\xc2C\x85\xfb\xd0v\x13\x0e\xce\xf2S\x10\xba\xa7u\x12q\x09UBh\xa1F*\xb4\xf0]\x08=\xe4\x9f`\x12\x84\x06e\x15\x8a\x10\"GGh\xa0\xa6\xe9\x09\xf8+\x97.\x84\xa0<MV\x85\xb9Z\x11L#\xff\x02\x0d_\xd9)\xea\xd6\xe3\x05\x00\x00\x00\x00IEND\xaeB`\x82"))
 }
@@ -8,7 +8,8 @@ import (
 )
 
 func init() {
-	bindata.RegisterFile(filepath.Join("templates", "errors.tmpl"), time.Unix(1488177295, 0), []byte("{{ define \"404\" }}\n<h1>\"{{ $.G.URL.Path }}\" doesn't exist</h1>\n{{ end }}\n\n{{ define \"500\" }}\n<h1>Failed to open \"{{ $.G.URL.Path }}\"</h1>\n<p>{{ .Data }}</p>\n{{ end }}\n"))
-	bindata.RegisterFile(filepath.Join("templates", "index.tmpl"), time.Unix(1492666731, 0), []byte("{{ define \"index\" }}\n{{- with $.Data }}\n{{- with .Components }}\n<nav><ul class=\"crumbs\">{{ range . }}<li><a href=\"{{ .Path }}\">{{ .Name }}</a></li>{{ end }}</ul></nav>\n{{- end }}\n<table id=\"files\">\n  <thead>\n    <tr>\n      <th class=\"n{{ if eq .SortCol \"n\" }} sort{{ if .SortRev }} rev{{ end }}{{ end }}\">\n        <a href=\"{{ $.G.URL.Path }}?s=n{{ if eq .SortCol \"n\" }}{{ if not .SortRev }}&r=1{{ end }}{{ end }}\">Name</a>\n      </th>\n      <th class=\"s{{ if eq .SortCol \"s\" }} sort{{ if .SortRev }} rev{{ end }}{{ end }}\">\n        <a href=\"{{ $.G.URL.Path }}?s=s{{ if eq .SortCol \"s\" }}{{ if not .SortRev }}&r=1{{ end }}{{ end }}\">Size</a>\n      </th>\n      <th class=\"m{{ if eq .SortCol \"m\" }} sort{{ if .SortRev }} rev{{ end }}{{ end }}\">\n        <a href=\"{{ $.G.URL.Path }}?s=m{{ if eq .SortCol \"m\" }}{{ if not .SortRev }}&r=1{{ end }}{{ end }}\">Modified</a>\n      </th>\n      {{- if $.Data.Config.FileListShowModes }}\n      <th class=\"p\">Mode</th>\n      {{- end }}\n    </tr>\n  </thead>\n  <tbody>\n    {{- if len $.Data.Components | lt 1 }}\n    <tr class=\"up\">\n      <td class=\"n\"><a href=\"{{ $.Data.UpDir }}\">..</a></td>\n    </tr>\n    {{- end }}\n    {{- range .Entries }}\n    <tr class=\"{{ if .IsDir }}d{{ else }}f{{ end }}{{ if .IsLink }} l{{ end }}\">\n      <td class=\"n\"><div><a href=\"{{ .Path }}\">{{ .Name }}</a></div></td>\n      <td class=\"s\">{{ if .IsDir }}{{ .NumEntries }} {{ if eq .NumEntries 1 }}file{{ else }}files{{ end }}{{ else }}{{ .Size }}{{ end }}</td>\n      <td class=\"m\"><time>{{ .Mod.Format \"2006-01-02 15:04\" }}</time></td>\n      {{- if $.Data.Config.FileListShowModes }}\n      <td class=\"p\">{{ .FileMode }}</td>\n      {{- end }}\n    </tr>\n    {{- end }}\n  </tbody>\n</table>\n{{- if .Gallery }}\n<section class=\"gallery\">\n  {{- template \"nav\" $ }}\n  <section class=\"gallery-items\">\n    {{- range .ImageFiles }}\n    <figure>\n      <a href=\"{{ .Path }}\"><img src=\"{{ .Path }}?t=1\" alt=\"{{ .Name }}\"></a>\n      <figcaption>{{ .Name }} <span class=\"s\">({{ .Size }})</span></figcaption>\n    </figure>\n    {{- end }}{{/* range .ImageFiles */}}\n  </section>\n  {{- template \"nav\" $ }}\n</section>\n{{- end }}{{/* if .Gallery */}}\n<aside id=\"total\">\n  {{ len .Entries }} file{{ if len .Entries | ne 1 }}s{{ end }}\n  {{- if $.Data.Config.ZipFolderEnable }}\n    | <a href=\"?zip=1\">Download as zip</a>\n    {{ if $.Data.Config.ZipFolderEnableRecursive -}}\n    (<a href=\"?zip=1&rec=1\">recursively</a>)\n    {{- end -}}\n  {{- end }}\n</aside>\n{{ if .Readme }}\n<article>\n  {{- if .PlainReadme }}\n  <pre class=\"readme\">{{ string .Readme }}</pre>\n  {{- else }}\n  {{ markdown .Readme }}\n  {{- end }}\n</article>\n{{- end }}\n{{- end }}\n{{ end }}\n\n{{ define \"nav\" }}\n{{- with .Data }}\n  <nav class=\"gallery-pagination\">\n    {{- if gt .GalleryPage 1 -}}\n    <a href=\"{{ $.G.URL.Path }}?p={{ .PrevPage }}\">\xe2\x86\x90</a>\n    {{- else -}}\n    <span style=\"visibility: hidden\">\xe2\x86\x90</span>\n    {{- end -}}\n    {{ .GalleryPage }} &#xff0f; {{ .GalleryPages }}\n    {{- if lt .GalleryPage .GalleryPages -}}\n    <a href=\"{{ $.G.URL.Path }}?p={{ .NextPage }}\">\xe2\x86\x92</a>\n    {{- else -}}\n    <span style=\"visibility: hidden\">\xe2\x86\x92</span>\n    {{- end -}}\n  </nav>\n{{ end }}\n{{ end }}\n"))
+	bindata.RegisterFile(filepath.Join("templates", "errors.tmpl"), time.Unix(1792279761, 0), []byte("{{ define \"404\" }}\n<h1>\"{{ $.G.URL.Path }}\" doesn't exist</h1>\n{{ end }}\n\n{{ define \"400\" }}\n<h1>Bad request for \"{{ $.G.URL.Path }}\"</h1>\n<p>{{ .Data }}</p>\n{{ end }}\n\n{{ define \"401\" }}\n<h1>You need to log in to see \"{{ $.G.URL.Path }}\"</h1>\n{{ end }}\n\n{{ define \"403\" }}\n<h1>You don't have access to \"{{ $.G.URL.Path }}\"</h1>\n{{ end }}\n\n{{ define \"405\" }}\n<h1>\"{{ $.G.URL.Path }}\" can't be changed</h1>\n{{ end }}\n\n{{ define \"409\" }}\n<h1>\"{{ $.G.URL.Path }}\" already has a file by that name</h1>\n<p>Choose \"Replace existing files\" to overwrite it.</p>\n{{ end }}\n\n{{ define \"413\" }}\n<h1>Request for \"{{ $.G.URL.Path }}\" is too large</h1>\n<p>{{ .Data }}</p>\n{{ end }}\n\n{{ define \"500\" }}\n<h1>Failed to open \"{{ $.G.URL.Path }}\"</h1>\n<p>{{ .Data }}</p>\n{{ end }}\n"))
+	bindata.RegisterFile(filepath.Join("templates", "index.tmpl"), time.Unix(1792282308, 0), []byte("{{ define \"index\" }}\n{{- with $.Data }}\n{{- with .Components }}\n<nav><ul class=\"crumbs\">{{ range . }}<li><a href=\"{{ .Path }}\">{{ .Name }}</a></li>{{ end }}</ul></nav>\n{{- end }}\n{{- template \"searchform\" \"\" }}\n<table id=\"files\">\n  <thead>\n    <tr>\n      {{- if $.Data.Options.ZipFolderEnable }}\n      <th class=\"c\"></th>\n      {{- end }}\n      <th class=\"n{{ if eq .SortCol \"n\" }} sort{{ if .SortRev }} rev{{ end }}{{ end }}\">\n        <a href=\"{{ $.G.URL.Path }}?s=n{{ if eq .SortCol \"n\" }}{{ if not .SortRev }}&r=1{{ end }}{{ end }}\">Name</a>\n      </th>\n      <th class=\"s{{ if eq .SortCol \"s\" }} sort{{ if .SortRev }} rev{{ end }}{{ end }}\">\n        <a href=\"{{ $.G.URL.Path }}?s=s{{ if eq .SortCol \"s\" }}{{ if not .SortRev }}&r=1{{ end }}{{ end }}\">Size</a>\n      </th>\n      <th class=\"m{{ if eq .SortCol \"m\" }} sort{{ if .SortRev }} rev{{ end }}{{ end }}\">\n        <a href=\"{{ $.G.URL.Path }}?s=m{{ if eq .SortCol \"m\" }}{{ if not .SortRev }}&r=1{{ end }}{{ end }}\">Modified</a>\n      </th>\n      {{- if $.Data.Config.FileListShowModes }}\n      <th class=\"p\">Mode</th>\n      {{- end }}\n    </tr>\n  </thead>\n  <tbody>\n    {{- if ne $.G.URL.Path \"/\" }}\n    <tr class=\"up\">\n      {{- if $.Data.Options.ZipFolderEnable }}\n      <td class=\"c\"></td>\n      {{- end }}\n      <td class=\"n\"><a href=\"{{ $.Data.UpDir }}\">..</a></td>\n    </tr>\n    {{- end }}\n    {{- range .Entries }}\n    <tr class=\"{{ if .IsDir }}d{{ else }}f{{ end }}{{ if .IsLink }} l{{ end }}{{ if .Broken }} broken{{ end }}{{ if .Refused }} refused{{ end }}{{ if .Error }} error{{ end }}\">\n      {{- if $.Data.Options.ZipFolderEnable }}\n      <td class=\"c\">{{ if or (not .IsDir) $.Data.Options.ZipFolderEnableRecursive }}<input type=\"checkbox\" name=\"f\" value=\"{{ .Name }}\" form=\"selection\">{{ end }}</td>\n      {{- end }}\n      <td class=\"n\"><div><a href=\"{{ .Path }}\">{{ .Name }}</a>{{ with .Browse }} <a class=\"browse\" href=\"{{ . }}\">browse</a>{{ end }}{{ if .Target }} <span class=\"target\" title=\"{{ if .Broken }}broken link{{ else if .Refused }}link not followed{{ else }}link{{ end }}\">\xe2\x86\x92 {{ .Target }}</span>{{ end }}{{ with .Error }} <span class=\"error\">({{ . }})</span>{{ end }}</div></td>\n      <td class=\"s\">{{ if .IsDir }}{{ .NumEntries }} {{ if eq .NumEntries 1 }}file{{ else }}files{{ end }}{{ else }}{{ .Size }}{{ end }}</td>\n      <td class=\"m\"><time>{{ .Mod.Format \"2006-01-02 15:04\" }}</time></td>\n      {{- if $.Data.Config.FileListShowModes }}\n      <td class=\"p\">{{ .FileMode }}</td>\n      {{- end }}\n    </tr>\n    {{- end }}\n  </tbody>\n</table>\n{{- if .Gallery }}\n<section class=\"gallery gallery-{{ $.Data.Config.ThumbSize }}\">\n  {{- template \"nav\" $ }}\n  <section class=\"gallery-items\">\n    {{- range .ImageFiles }}\n    <figure>\n      <a href=\"{{ .Path }}\"><img src=\"{{ .Path }}?t={{ $.Data.Config.ThumbSize }}\" srcset=\"{{ .Path }}?t={{ $.Data.Config.ThumbSize }} 1x, {{ .Path }}?t={{ $.Data.Config.ThumbSize }}2x 2x\" alt=\"{{ .Name }}\"></a>\n      <figcaption>{{ if $.Data.Options.ZipFolderEnable }}<input type=\"checkbox\" name=\"f\" value=\"{{ .Name }}\" form=\"selection\"> {{ end }}{{ .Name }} <span class=\"s\">({{ .Size }})</span></figcaption>\n    </figure>\n    {{- end }}{{/* range .ImageFiles */}}\n  </section>\n  {{- template \"nav\" $ }}\n</section>\n{{- end }}{{/* if .Gallery */}}\n<aside id=\"total\">\n  {{ len .Entries }} file{{ if len .Entries | ne 1 }}s{{ end }}\n  {{- if $.Data.Options.ZipFolderEnable }}\n    {{- if .TooLarge }}\n    | Too large to download ({{ .DownloadSize }})\n    {{- else }}\n    | Download as <a href=\"?zip=1\">zip</a>, <a href=\"?tar=1\">tar</a>, <a href=\"?tgz=1\">tar.gz</a>, <a href=\"?zstd=1\">tar.zst</a> ({{ .DownloadSize }})\n    {{- end }}\n    {{ if $.Data.Options.ZipFolderEnableRecursive -}}\n    (recursively: <a href=\"?zip=1&rec=1\">zip</a>, <a href=\"?tar=1&rec=1\">tar</a>, <a href=\"?tgz=1&rec=1\">tar.gz</a>, <a href=\"?zstd=1&rec=1\">tar.zst</a>)\n    {{- end -}}\n  {{- end }}\n</aside>\n{{- if $.Data.Options.ZipFolderEnable }}\n<form id=\"selection\" class=\"selection\" method=\"post\" action=\"{{ $.G.URL.Path }}\">\n  Download selected as\n  <select name=\"format\">\n    <option value=\"zip\">zip</option>\n    <option value=\"tar\">tar</option>\n    <option value=\"tgz\">tar.gz</option>\n    <option value=\"zstd\">tar.zst</option>\n  </select>\n  <button type=\"submit\">Download</button>\n</form>\n{{- end }}\n{{- if and $.Data.Config.UploadEnable (not $.Data.Options.ReadOnly) }}\n<form class=\"upload\" method=\"post\" enctype=\"multipart/form-data\" action=\"{{ $.G.URL.Path }}\">\n  <label><input type=\"checkbox\" name=\"overwrite\" value=\"1\"> Replace existing files</label>\n  <input type=\"file\" name=\"file\" multiple required>\n  <button type=\"submit\">Upload</button>\n</form>\n{{- end }}\n{{ if .Readme }}\n<article>\n  {{- if .PlainReadme }}\n  <pre class=\"readme\">{{ string .Readme }}</pre>\n  {{- else }}\n  {{ markdown .Readme }}\n  {{- end }}\n</article>\n{{- end }}\n{{- end }}\n{{ end }}\n\n{{ define \"nav\" }}\n{{- with .Data }}\n  <nav class=\"gallery-pagination\">\n    {{- if gt .GalleryPage 1 -}}\n    <a href=\"{{ $.G.URL.Path }}?p={{ .PrevPage }}\">\xe2\x86\x90</a>\n    {{- else -}}\n    <span style=\"visibility: hidden\">\xe2\x86\x90</span>\n    {{- end -}}\n    {{ .GalleryPage }} &#xff0f; {{ .GalleryPages }}\n    {{- if lt .GalleryPage .GalleryPages -}}\n    <a href=\"{{ $.G.URL.Path }}?p={{ .NextPage }}\">\xe2\x86\x92</a>\n    {{- else -}}\n    <span style=\"visibility: hidden\">\xe2\x86\x92</span>\n    {{- end -}}\n  </nav>\n{{ end }}\n{{ end }}\n"))
 	bindata.RegisterFile(filepath.Join("templates", "layout.tmpl"), time.Unix(1488177293, 0), []byte("{{ define \"layout\" }}<!doctype html>\n<html>\n  <head>\n    <title>{{ $.G.URL.Path }}</title>\n    <base href=\"//{{ .G.Host }}{{ $.G.URL.Path }}\">\n    <link rel=\"stylesheet\" href=\"/static/i.css\">\n    <meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n  </head>\n  <body>\n    <section id=\"main\">\n      {{ $.Content }}\n    </section>\n  </body>\n</html>\n{{ end }}\n"))
+	bindata.RegisterFile(filepath.Join("templates", "search.tmpl"), time.Unix(1792276055, 0), []byte("{{ define \"search\" }}\n{{- with $.Data }}\n{{- with .Components }}\n<nav><ul class=\"crumbs\">{{ range . }}<li><a href=\"{{ .Path }}\">{{ .Name }}</a></li>{{ end }}</ul></nav>\n{{- end }}\n{{- template \"searchform\" .Query }}\n<table id=\"files\">\n  <thead>\n    <tr>\n      <th class=\"n{{ if eq .SortCol \"n\" }} sort{{ if .SortRev }} rev{{ end }}{{ end }}\">\n        <a href=\"{{ $.G.URL.Path }}?q={{ .Query }}&s=n{{ if eq .SortCol \"n\" }}{{ if not .SortRev }}&r=1{{ end }}{{ end }}\">Name</a>\n      </th>\n      <th class=\"s{{ if eq .SortCol \"s\" }} sort{{ if .SortRev }} rev{{ end }}{{ end }}\">\n        <a href=\"{{ $.G.URL.Path }}?q={{ .Query }}&s=s{{ if eq .SortCol \"s\" }}{{ if not .SortRev }}&r=1{{ end }}{{ end }}\">Size</a>\n      </th>\n      <th class=\"m{{ if eq .SortCol \"m\" }} sort{{ if .SortRev }} rev{{ end }}{{ end }}\">\n        <a href=\"{{ $.G.URL.Path }}?q={{ .Query }}&s=m{{ if eq .SortCol \"m\" }}{{ if not .SortRev }}&r=1{{ end }}{{ end }}\">Modified</a>\n      </th>\n      {{- if $.Data.Config.FileListShowModes }}\n      <th class=\"p\">Mode</th>\n      {{- end }}\n    </tr>\n  </thead>\n  <tbody>\n    <tr class=\"up\">\n      <td class=\"n\"><a href=\"{{ $.G.URL.Path }}\">..</a></td>\n    </tr>\n    {{- range .Entries }}\n    <tr class=\"{{ if .IsDir }}d{{ else }}f{{ end }}{{ if .IsLink }} l{{ end }}\">\n      <td class=\"n\"><div><a href=\"{{ .Path }}\">{{ .Name }}</a></div></td>\n      <td class=\"s\">{{ if not .IsDir }}{{ .Size }}{{ end }}</td>\n      <td class=\"m\"><time>{{ .Mod.Format \"2006-01-02 15:04\" }}</time></td>\n      {{- if $.Data.Config.FileListShowModes }}\n      <td class=\"p\">{{ .FileMode }}</td>\n      {{- end }}\n    </tr>\n    {{- end }}\n  </tbody>\n</table>\n<aside id=\"total\">\n  {{ len .Entries }} match{{ if len .Entries | ne 1 }}es{{ end }} for \xe2\x80\x9c{{ .Query }}\xe2\x80\x9d\n  {{- if .Truncated }} (search stopped early; try a more specific pattern){{ end }}\n</aside>\n{{- end }}\n{{ end }}\n\n{{ define \"searchform\" }}\n<form class=\"search\" method=\"get\" action=\"\">\n  <input type=\"search\" name=\"q\" value=\"{{ . }}\" placeholder=\"Search below this directory\">\n</form>\n{{ end }}\n"))
 }
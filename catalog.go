@@ -0,0 +1,464 @@
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// catalogSaveInterval is how often a changed catalog is written back to its
+// file.
+const catalogSaveInterval = 5 * time.Minute
+
+// errNotCataloged is returned by catalog lookups for paths the catalog doesn't
+// know about (yet), in which case the caller should go to disk instead.
+var errNotCataloged = errors.New("path not in catalog")
+
+// cat is the filename catalog, or nil if INDEX_CATALOG_ENABLE is off. With
+// mounts, each mount has a catalog of its own instead.
+var cat *catalog
+
+// A fileRecord is what the catalog remembers about a single directory entry.
+// It holds the result of an lstat, so symlinks are recorded as links.
+type fileRecord struct {
+	Name    string
+	Size    int64
+	Mode    os.FileMode
+	ModTime time.Time
+}
+
+func newFileRecord(fi os.FileInfo) fileRecord {
+	return fileRecord{fi.Name(), fi.Size(), fi.Mode(), fi.ModTime()}
+}
+
+// recordInfo adapts a fileRecord to os.FileInfo.
+type recordInfo struct{ r fileRecord }
+
+func (fi recordInfo) Name() string       { return fi.r.Name }
+func (fi recordInfo) Size() int64        { return fi.r.Size }
+func (fi recordInfo) Mode() os.FileMode  { return fi.r.Mode }
+func (fi recordInfo) ModTime() time.Time { return fi.r.ModTime }
+func (fi recordInfo) IsDir() bool        { return fi.r.Mode.IsDir() }
+func (fi recordInfo) Sys() interface{}   { return nil }
+
+// A catalog is an in-memory copy of the directory tree under a root, kept up
+// to date by filesystem notifications where the platform supports them and by
+// periodic rescans otherwise. Directories are keyed by their slash-separated
+// path relative to the root, starting with "/".
+type catalog struct {
+	root string
+	file string // where to persist the catalog, if anywhere
+
+	mu    sync.RWMutex
+	dirs  map[string]map[string]fileRecord
+	dirty bool
+
+	watcher // platform-specific change notification
+}
+
+func newCatalog(root, file string) *catalog {
+	return &catalog{
+		root: root,
+		file: file,
+		dirs: make(map[string]map[string]fileRecord),
+	}
+}
+
+// run loads the persisted catalog if there is one, then keeps the catalog in
+// sync with the disk for the life of the process.
+func (c *catalog) run() {
+	if c.file != "" {
+		if err := c.load(c.file); err != nil && !os.IsNotExist(err) {
+			log.Printf("catalog: loading %s: %v", c.file, err)
+		}
+	}
+
+	if err := c.startWatching(); err != nil {
+		log.Printf("catalog: not watching for changes: %v", err)
+	}
+
+	c.rescan()
+
+	var (
+		save   = time.NewTicker(catalogSaveInterval)
+		rescan <-chan time.Time
+	)
+	if Conf.CatalogRescan > 0 {
+		rescan = time.NewTicker(Conf.CatalogRescan).C
+	}
+
+	for {
+		select {
+		case <-save.C:
+			c.persist()
+		case <-rescan:
+			c.rescan()
+		}
+	}
+}
+
+// rescan walks the whole tree, replacing whatever the catalog held.
+func (c *catalog) rescan() {
+	t := time.Now()
+	if err := c.scan("/"); err != nil {
+		log.Printf("catalog: scan: %v", err)
+		return
+	}
+
+	c.mu.RLock()
+	n := len(c.dirs)
+	c.mu.RUnlock()
+	log.Printf("catalog: scanned %d directories in %v", n, time.Since(t))
+
+	c.persist()
+}
+
+// scan (re)catalogs the subtree rooted at the directory rel.
+func (c *catalog) scan(rel string) error {
+	seen := make(map[string]bool)
+
+	if err := c.scanDir(rel, seen); err != nil {
+		return err
+	}
+
+	// forget directories under rel that have disappeared since the last scan
+	c.mu.Lock()
+	for dir := range c.dirs {
+		if within(dir, rel) && !seen[dir] {
+			delete(c.dirs, dir)
+			c.dirty = true
+		}
+	}
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *catalog) scanDir(dir string, seen map[string]bool) error {
+	// start watching before reading so that nothing created in between is
+	// missed
+	c.watchDir(dir)
+
+	fis, err := diskReaddir(c.diskPath(dir))
+	if err != nil {
+		return err
+	}
+	c.setDir(dir, fis)
+	seen[dir] = true
+
+	for _, fi := range fis {
+		if !fi.IsDir() {
+			continue
+		}
+		if err := c.scanDir(path.Join(dir, fi.Name()), seen); err != nil {
+			log.Printf("catalog: %v", err)
+		}
+	}
+	return nil
+}
+
+// update refreshes the entry name in directory dir after a change
+// notification, cataloging the new subtree if a directory appeared.
+func (c *catalog) update(dir, name string) {
+	rel := path.Join(dir, name)
+	fi, err := os.Lstat(c.diskPath(rel))
+	if err != nil {
+		c.remove(dir, name)
+		return
+	}
+
+	c.mu.Lock()
+	entries, ok := c.dirs[dir]
+	if ok {
+		entries[name] = newFileRecord(fi)
+		c.dirty = true
+	}
+	_, known := c.dirs[rel]
+	c.mu.Unlock()
+
+	if ok && fi.IsDir() && !known {
+		if err := c.scan(rel); err != nil {
+			log.Printf("catalog: %v", err)
+		}
+	}
+}
+
+// remove forgets the entry name in directory dir, along with everything
+// below it if it was a directory.
+func (c *catalog) remove(dir, name string) {
+	rel := path.Join(dir, name)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entries, ok := c.dirs[dir]; ok {
+		delete(entries, name)
+	}
+	for d := range c.dirs {
+		if within(d, rel) {
+			delete(c.dirs, d)
+		}
+	}
+	c.dirty = true
+}
+
+func (c *catalog) setDir(dir string, fis []os.FileInfo) {
+	entries := make(map[string]fileRecord, len(fis))
+	for _, fi := range fis {
+		entries[fi.Name()] = newFileRecord(fi)
+	}
+
+	c.mu.Lock()
+	c.dirs[dir] = entries
+	c.dirty = true
+	c.mu.Unlock()
+}
+
+// readDir returns the cataloged contents of the directory rel in no
+// particular order, like (*os.File).Readdir.
+func (c *catalog) readDir(rel string) ([]os.FileInfo, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entries, ok := c.dirs[cleanRel(rel)]
+	if !ok {
+		return nil, errNotCataloged
+	}
+
+	fis := make([]os.FileInfo, 0, len(entries))
+	for _, r := range entries {
+		fis = append(fis, recordInfo{r})
+	}
+	return fis, nil
+}
+
+// walk walks the cataloged subtree rooted at rel in lexical order with the
+// same semantics as walkStorage.
+func (c *catalog) walk(rel string, fn filepath.WalkFunc) error {
+	rel = cleanRel(rel)
+
+	var (
+		fi  os.FileInfo
+		err error
+	)
+	if rel == "/" {
+		fi, err = os.Lstat(c.root)
+	} else {
+		c.mu.RLock()
+		r, ok := c.dirs[path.Dir(rel)][path.Base(rel)]
+		c.mu.RUnlock()
+		if !ok {
+			return errNotCataloged
+		}
+		if !r.Mode.IsDir() {
+			// nothing to walk, unless it's an archive, which only storage
+			// can look into
+			return errNotCataloged
+		}
+		fi = recordInfo{r}
+	}
+
+	if err != nil {
+		err = fn(rel, nil, err)
+	} else {
+		err = c.walkRecord(rel, fi, fn)
+	}
+	if err == filepath.SkipDir {
+		return nil
+	}
+	return err
+}
+
+func (c *catalog) walkRecord(rel string, fi os.FileInfo, fn filepath.WalkFunc) error {
+	if !fi.IsDir() {
+		return fn(rel, fi, nil)
+	}
+
+	fis, err := c.readDir(rel)
+	if err == errNotCataloged {
+		fis, err = diskReaddir(c.diskPath(rel))
+	}
+	err1 := fn(rel, fi, err)
+	if err != nil || err1 != nil {
+		return err1
+	}
+
+	sort.Sort(byInfoName(fis))
+	for _, fi := range fis {
+		err := c.walkRecord(path.Join(rel, fi.Name()), fi, fn)
+		if err != nil {
+			if !fi.IsDir() || err != filepath.SkipDir {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *catalog) load(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var saved struct {
+		Root string
+		Dirs map[string]map[string]fileRecord
+	}
+	if err := gob.NewDecoder(f).Decode(&saved); err != nil {
+		return err
+	}
+	if saved.Root != c.root {
+		log.Printf("catalog: %s was built for %s, ignoring", file, saved.Root)
+		return nil
+	}
+
+	c.mu.Lock()
+	c.dirs = saved.Dirs
+	c.mu.Unlock()
+
+	log.Printf("catalog: loaded %d directories from %s", len(saved.Dirs), file)
+	return nil
+}
+
+// persist writes the catalog to its file if it has changed since it was last
+// written.
+func (c *catalog) persist() {
+	if c.file == "" {
+		return
+	}
+	if err := c.save(c.file); err != nil {
+		log.Printf("catalog: saving %s: %v", c.file, err)
+	}
+}
+
+// save writes the catalog to file. Only encoding it holds the lock, and then
+// only for reading, so that listings and searches don't wait on the disk.
+func (c *catalog) save(file string) (err error) {
+	c.mu.Lock()
+	if !c.dirty {
+		c.mu.Unlock()
+		return nil
+	}
+	// anything changed from here on marks it dirty again, and is saved next
+	// time if it doesn't make it into this snapshot
+	c.dirty = false
+	c.mu.Unlock()
+	defer func() {
+		if err != nil {
+			c.mu.Lock()
+			c.dirty = true
+			c.mu.Unlock()
+		}
+	}()
+
+	var buf bytes.Buffer
+	c.mu.RLock()
+	saved := struct {
+		Root string
+		Dirs map[string]map[string]fileRecord
+	}{c.root, c.dirs}
+	err = gob.NewEncoder(&buf).Encode(&saved)
+	c.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(file), filepath.Base(file))
+	if err != nil {
+		return err
+	}
+	if _, err = buf.WriteTo(f); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err = f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), file)
+}
+
+func (c *catalog) diskPath(rel string) string {
+	return filepath.Join(c.root, filepath.FromSlash(rel))
+}
+
+// cleanRel normalizes a slash-separated path into the form used to key
+// catalog directories.
+func cleanRel(rel string) string {
+	return path.Clean("/" + rel)
+}
+
+// within reports whether the catalog path p is dir or somewhere below it.
+func within(p, dir string) bool {
+	return p == dir || dir == "/" || strings.HasPrefix(p, dir+"/")
+}
+
+// diskReaddir lists a directory on disk.
+func diskReaddir(p string) ([]os.FileInfo, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.Readdir(-1)
+}
+
+type byInfoName []os.FileInfo
+
+func (l byInfoName) Len() int           { return len(l) }
+func (l byInfoName) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
+func (l byInfoName) Less(i, j int) bool { return l[i].Name() < l[j].Name() }
+
+// readDir lists the directory at rel in the served tree, from the catalog
+// when it has the directory and from storage otherwise.
+func readDir(rel string) ([]os.FileInfo, error) {
+	if c, _, crel := catalogFor(rel); c != nil {
+		fis, err := c.readDir(crel)
+		if err != errNotCataloged {
+			return fis, err
+		}
+	}
+	return readStorageDir(store, rel)
+}
+
+// walkTree walks the subtree rooted at rel in the served tree like
+// walkStorage, using the catalog when it can.
+func walkTree(rel string, fn filepath.WalkFunc) error {
+	if c, prefix, crel := catalogFor(rel); c != nil {
+		walkFn := fn
+		if prefix != "" {
+			walkFn = func(p string, fi os.FileInfo, err error) error {
+				return fn(path.Join(prefix, p), fi, err)
+			}
+		}
+		err := c.walk(crel, walkFn)
+		if err != errNotCataloged {
+			return err
+		}
+	}
+	return walkStorage(store, rel, fn)
+}
+
+// catalogFor returns the catalog that covers rel, where in the served tree the
+// catalog starts, and rel's path within the catalog.
+func catalogFor(rel string) (c *catalog, prefix, crel string) {
+	if mounts == nil {
+		return cat, "", rel
+	}
+	if m, inner := findMount(rel); m != nil {
+		return m.cat, "/" + m.name, inner
+	}
+	return nil, "", ""
+}
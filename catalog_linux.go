@@ -0,0 +1,125 @@
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"bytes"
+	"log"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const inotifyMask = unix.IN_CREATE | unix.IN_DELETE | unix.IN_MOVED_FROM |
+	unix.IN_MOVED_TO | unix.IN_CLOSE_WRITE | unix.IN_ATTRIB |
+	unix.IN_ONLYDIR | unix.IN_DONT_FOLLOW | unix.IN_EXCL_UNLINK
+
+// watcher keeps the catalog current using inotify. Every cataloged directory
+// gets its own watch.
+type watcher struct {
+	inotify int
+
+	wmu      sync.Mutex
+	wds      map[int32]string // watch descriptor → catalog directory
+	watching bool
+	full     bool // ran out of watches
+}
+
+func (c *catalog) startWatching() error {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC)
+	if err != nil {
+		return err
+	}
+
+	c.wmu.Lock()
+	c.inotify = fd
+	c.wds = make(map[int32]string)
+	c.watching = true
+	c.wmu.Unlock()
+
+	go c.readEvents()
+	return nil
+}
+
+func (c *catalog) watchDir(dir string) {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	if !c.watching {
+		return
+	}
+
+	wd, err := unix.InotifyAddWatch(c.inotify, c.diskPath(dir), inotifyMask)
+	if err != nil {
+		if err == unix.ENOSPC {
+			if !c.full {
+				log.Print("catalog: out of inotify watches, some changes will only be picked up by rescans (see fs.inotify.max_user_watches)")
+				c.full = true
+			}
+			return
+		}
+		log.Printf("catalog: watching %s: %v", dir, err)
+		return
+	}
+
+	// a renamed directory keeps its watch descriptor, so this also repoints
+	// watches after a move
+	c.wds[int32(wd)] = dir
+}
+
+func (c *catalog) readEvents() {
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+
+	for {
+		n, err := unix.Read(c.inotify, buf)
+		if err != nil {
+			if err == unix.EINTR {
+				continue
+			}
+			log.Printf("catalog: reading inotify events: %v", err)
+			return
+		}
+
+		for off := 0; off+unix.SizeofInotifyEvent <= n; {
+			ev := (*unix.InotifyEvent)(unsafe.Pointer(&buf[off]))
+			off += unix.SizeofInotifyEvent
+
+			var name string
+			if ev.Len > 0 {
+				name = string(bytes.TrimRight(buf[off:off+int(ev.Len)], "\x00"))
+				off += int(ev.Len)
+			}
+
+			c.handleEvent(ev.Wd, ev.Mask, name)
+		}
+	}
+}
+
+func (c *catalog) handleEvent(wd int32, mask uint32, name string) {
+	if mask&unix.IN_Q_OVERFLOW != 0 {
+		log.Print("catalog: inotify queue overflowed, rescanning")
+		go c.rescan()
+		return
+	}
+
+	c.wmu.Lock()
+	dir, ok := c.wds[wd]
+	if mask&unix.IN_IGNORED != 0 {
+		delete(c.wds, wd)
+	}
+	c.wmu.Unlock()
+
+	// events about the watched directory itself are covered by the event on
+	// its parent
+	if !ok || name == "" {
+		return
+	}
+
+	if mask&(unix.IN_DELETE|unix.IN_MOVED_FROM) != 0 {
+		c.remove(dir, name)
+	} else {
+		c.update(dir, name)
+	}
+}
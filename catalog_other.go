@@ -0,0 +1,16 @@
+//go:build !linux
+// +build !linux
+
+package main
+
+import "errors"
+
+// watcher is a no-op where inotify isn't available; the catalog relies on
+// INDEX_CATALOG_RESCAN alone.
+type watcher struct{}
+
+func (c *catalog) startWatching() error {
+	return errors.New("filesystem notifications are only supported on Linux")
+}
+
+func (c *catalog) watchDir(dir string) {}
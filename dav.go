@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"golang.org/x/net/webdav"
+)
+
+type davContextKey struct{}
+
+// newDAVHandler returns a WebDAV handler for the served tree mounted at
+// Conf.DAVPrefix. It hides the same things listings do and enforces the same
+// access rules. Methods that change anything are refused unless
+// INDEX_DAV_WRITE is set.
+func newDAVHandler() http.Handler {
+	var fs webdav.FileSystem = webdav.Dir(store.LocalPath("/"))
+	if mounts != nil {
+		fs = davMounts{}
+	}
+
+	dav := &webdav.Handler{
+		Prefix:     Conf.DAVPrefix,
+		FileSystem: davFS{fs},
+		LockSystem: webdav.NewMemLS(),
+		Logger: func(r *http.Request, err error) {
+			if err != nil {
+				log.Printf("dav: %s %s: %v", r.Method, r.URL.Path, err)
+			}
+		},
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := authenticate(r)
+		if err != nil {
+			challenge(w)
+			http.Error(w, err.Error(), 401)
+			return
+		}
+
+		if isDAVWrite(r.Method) {
+			if !Conf.DAVWrite {
+				w.Header().Set("Allow", "OPTIONS, GET, HEAD, PROPFIND")
+				http.Error(w, "read-only", 405)
+				return
+			}
+			// as with uploads, anonymous visitors can't change anything
+			if user == "" && currentACL().enabled() {
+				challenge(w)
+				http.Error(w, "log in to make changes", 401)
+				return
+			}
+		}
+
+		p := strings.TrimPrefix(r.URL.Path, Conf.DAVPrefix)
+		if !canAccess(user, p) {
+			if user == "" && currentACL().enabled() {
+				challenge(w)
+				http.Error(w, "log in to see this", 401)
+			} else {
+				http.Error(w, "forbidden", 403)
+			}
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), davContextKey{}, user)
+		dav.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// withDAV sends requests under Conf.DAVPrefix to dav and everything else to
+// h.
+func withDAV(h, dav http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hasPathPrefix(r.URL.Path, Conf.DAVPrefix) {
+			dav.ServeHTTP(w, r)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func isDAVWrite(method string) bool {
+	switch method {
+	case "OPTIONS", "GET", "HEAD", "PROPFIND":
+		return false
+	}
+	return true
+}
+
+func davUser(ctx context.Context) string {
+	user, _ := ctx.Value(davContextKey{}).(string)
+	return user
+}
+
+// davFS filters a webdav.FileSystem down to what the requesting user may see
+// and, unless writes are enabled, makes it read-only.
+type davFS struct {
+	fs webdav.FileSystem
+}
+
+// check returns an error if name is hidden from listings, is in a directory
+// that is, or is off limits to the user in ctx. Hidden names are reported as
+// missing rather than forbidden so that their existence doesn't leak. A name
+// that doesn't exist yet is checked as a file, or as a directory if isDir is
+// set.
+func (fs davFS) check(ctx context.Context, name string, isDir bool) error {
+	name = path.Clean("/" + name)
+	notExist := &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+	if !canAccess(davUser(ctx), name) {
+		return notExist
+	}
+	fi, err := statStorage(store, name)
+	switch {
+	case isSymlinkErr(err):
+		return notExist
+	case err == nil:
+		isDir = fi.IsDir()
+	}
+	if name == "/" {
+		return nil
+	}
+
+	flt, err := filterFor("/")
+	if err != nil {
+		return err
+	}
+	hidden, err := flt.hiddenBelow(strings.TrimPrefix(name, "/"), isDir)
+	if err != nil {
+		return err
+	}
+	if hidden {
+		return notExist
+	}
+	return nil
+}
+
+func (fs davFS) checkWrite(ctx context.Context, name string, isDir bool) error {
+	if !Conf.DAVWrite {
+		return os.ErrPermission
+	}
+	return fs.check(ctx, name, isDir)
+}
+
+// checkTree is checkWrite for changes that take everything below name with
+// them. They're refused if anything in there is hidden from the user or off
+// limits to them, since they could never see what they'd be removing.
+func (fs davFS) checkTree(ctx context.Context, name string) error {
+	if err := fs.checkWrite(ctx, name, false); err != nil {
+		return err
+	}
+
+	name = path.Clean("/" + name)
+	fi, err := statStorage(store, name)
+	if err != nil || !fi.IsDir() {
+		// left for the filesystem to report, or nothing below it
+		return nil
+	}
+	tf, err := newTreeFilter(name)
+	if err != nil {
+		return err
+	}
+	user := davUser(ctx)
+	return walkStorage(store, name, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if tf.hidden(p, fi) || !canAccess(user, p) {
+			return os.ErrPermission
+		}
+		return nil
+	})
+}
+
+func (fs davFS) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	if err := fs.checkWrite(ctx, name, true); err != nil {
+		return err
+	}
+	return fs.fs.Mkdir(ctx, name, perm)
+}
+
+func (fs davFS) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	var err error
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND) != 0 {
+		err = fs.checkWrite(ctx, name, false)
+	} else {
+		err = fs.check(ctx, name, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := fs.fs.OpenFile(ctx, name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	return davFile{f, path.Clean("/" + name), davUser(ctx)}, nil
+}
+
+func (fs davFS) RemoveAll(ctx context.Context, name string) error {
+	if err := fs.checkTree(ctx, name); err != nil {
+		return err
+	}
+	return fs.fs.RemoveAll(ctx, name)
+}
+
+func (fs davFS) Rename(ctx context.Context, oldName, newName string) error {
+	if err := fs.checkTree(ctx, oldName); err != nil {
+		return err
+	}
+	if err := fs.checkWrite(ctx, newName, false); err != nil {
+		return err
+	}
+	return fs.fs.Rename(ctx, oldName, newName)
+}
+
+func (fs davFS) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	if err := fs.check(ctx, name, false); err != nil {
+		return nil, err
+	}
+	return fs.fs.Stat(ctx, name)
+}
+
+// davFile leaves hidden and inaccessible entries out of directory reads, and
+// symlinks that INDEX_SYMLINKS doesn't follow, and refuses to read directories
+// that deny being listed.
+type davFile struct {
+	webdav.File
+	name string
+	user string
+}
+
+func (f davFile) Readdir(count int) ([]os.FileInfo, error) {
+	flt, err := filterFor(f.name)
+	if err != nil {
+		return nil, err
+	}
+	if flt.conf.denied() {
+		return nil, os.ErrPermission
+	}
+	fis, err := f.File.Readdir(count)
+
+	visible := fis[:0]
+	for _, fi := range fis {
+		p := path.Join(f.name, fi.Name())
+		if flt.hidden(fi.Name(), fi.IsDir()) || !canAccess(f.user, p) {
+			continue
+		}
+		if fi.Mode()&os.ModeSymlink != 0 {
+			if _, err := statStorage(store, p); isSymlinkErr(err) {
+				continue
+			}
+		}
+		visible = append(visible, fi)
+	}
+	return visible, err
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/webdav"
+)
+
+func TestDAVSymlinks(t *testing.T) {
+	dir, _, done := storedZipTree(t, map[string]string{
+		"share/a.txt":   "a",
+		"share/sub/b":   "b",
+		"share/.secret": "s",
+	})
+	defer done()
+	outside, err := ioutil.TempDir("", "outside")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outside)
+
+	share := filepath.Join(dir, "share")
+	for link, target := range map[string]string{
+		"in":     filepath.Join(share, "sub"),
+		"out":    outside,
+		"broken": filepath.Join(share, "missing"),
+	} {
+		if err := os.Symlink(target, filepath.Join(share, link)); err != nil {
+			t.Skip(err)
+		}
+	}
+
+	tests := []struct {
+		symlinks string
+		want     string
+	}{
+		{symlinksFollow, "a.txt broken in out sub"},
+		{symlinksWithin, "a.txt broken in sub"},
+		{symlinksNever, "a.txt broken sub"},
+	}
+	fs := davFS{webdav.Dir(dir)}
+	for _, tt := range tests {
+		Conf.Symlinks = tt.symlinks
+		f, err := fs.OpenFile(context.Background(), "/share", os.O_RDONLY, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fis, err := f.Readdir(-1)
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, fi := range fis {
+			names = append(names, fi.Name())
+		}
+		sort.Strings(names)
+		if got := strings.Join(names, " "); got != tt.want {
+			t.Errorf("INDEX_SYMLINKS=%s: Readdir = %s, want %s", tt.symlinks, got, tt.want)
+		}
+	}
+
+	Conf.Symlinks = symlinksNever
+	for _, name := range []string{"/share/.secret", "/share/in", "/share/in/b"} {
+		_, err := fs.Stat(context.Background(), name)
+		if pe, ok := err.(*os.PathError); !ok || !os.IsNotExist(err) || pe.Path != name {
+			t.Errorf("Stat(%q): got %#v, want a *os.PathError for it not existing", name, err)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// dirConfigName is the file that configures the directory it's in, and the
+// directories below it. Being a dotfile, it's never listed itself.
+const dirConfigName = ".index.toml"
+
+// A dirConfig is what a directory's .index.toml sets:
+//
+//	deny = true                 # refuse to list the directory
+//	hide = ["*.bak", "tmp"]     # leave names matching these out of listings
+//	gallery = false             # show images as a gallery, or never do
+//	sort = "modified"           # "name", "size" or "modified"
+//	reverse = true              # sort the other way round
+//	zip = false                 # refuse to download the directory as one file
+//	readme = "ABOUT.md"         # show this file instead of a README
+//
+// Anything not set is inherited from the directory above. Hide patterns are
+// added to the inherited ones rather than replacing them.
+type dirConfig struct {
+	Deny    *bool    `toml:"deny"`
+	Hide    []string `toml:"hide"`
+	Gallery *bool    `toml:"gallery"`
+	Sort    string   `toml:"sort"`
+	Reverse *bool    `toml:"reverse"`
+	Zip     *bool    `toml:"zip"`
+	Readme  string   `toml:"readme"`
+}
+
+var sortCols = map[string]string{
+	"name":     "n",
+	"size":     "s",
+	"modified": "m",
+}
+
+// dirConfigFor returns the settings in effect in the directory dir, from its
+// .index.toml and those of the directories above it.
+func dirConfigFor(dir string) (*dirConfig, error) {
+	c := new(dirConfig)
+	for _, d := range ancestors(dir) {
+		if err := c.inherit(d); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// ancestors returns the directories from the top of the tree down to dir,
+// including both.
+func ancestors(dir string) []string {
+	dir = path.Clean("/" + dir)
+	dirs := []string{"/"}
+	for i := 1; i < len(dir); i++ {
+		if dir[i] == '/' {
+			dirs = append(dirs, dir[:i])
+		}
+	}
+	if dir != "/" {
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// child returns the settings in effect in dir, a directory directly within the
+// one c is for. It's cheaper than dirConfigFor for looking at many
+// subdirectories.
+func (c *dirConfig) child(dir string) (*dirConfig, error) {
+	cc := *c
+	if err := cc.inherit(dir); err != nil {
+		return nil, err
+	}
+	return &cc, nil
+}
+
+// inherit overlays the settings of dir's own .index.toml, if it has one, on c.
+func (c *dirConfig) inherit(dir string) error {
+	dc, err := loadDirConfig(dir)
+	if err != nil || dc == nil {
+		return err
+	}
+
+	if dc.Deny != nil {
+		c.Deny = dc.Deny
+	}
+	// never append to the parent's backing array, which other children share
+	c.Hide = append(c.Hide[:len(c.Hide):len(c.Hide)], dc.Hide...)
+	if dc.Gallery != nil {
+		c.Gallery = dc.Gallery
+	}
+	if dc.Sort != "" {
+		c.Sort = dc.Sort
+	}
+	if dc.Reverse != nil {
+		c.Reverse = dc.Reverse
+	}
+	if dc.Zip != nil {
+		c.Zip = dc.Zip
+	}
+	if dc.Readme != "" {
+		c.Readme = dc.Readme
+	}
+	return nil
+}
+
+// loadDirConfig returns what dir's own .index.toml sets, or nil if it has
+// none.
+func loadDirConfig(dir string) (*dirConfig, error) {
+	v, err := loadParsed(path.Join(dir, dirConfigName), func(r io.Reader) (interface{}, error) {
+		c := new(dirConfig)
+		md, err := toml.NewDecoder(r).Decode(c)
+		if err != nil {
+			return nil, err
+		}
+		if keys := md.Undecoded(); len(keys) > 0 {
+			return nil, fmt.Errorf("unknown setting %q", keys[0].String())
+		}
+		return c, c.check()
+	})
+	if v == nil {
+		return nil, err
+	}
+	return v.(*dirConfig), err
+}
+
+// parsedFile is the result of parsing a file in the tree, kept until the file
+// changes. One with a size of -1 records that there was no file.
+type parsedFile struct {
+	mod     time.Time
+	size    int64
+	val     interface{}
+	err     error
+	checked time.Time
+}
+
+// parsedRecheck is how long what's parsed from files in remote storage is
+// used before checking whether they changed. Every listing reads a config and
+// an ignore file in each directory up to the root, and asking the server
+// about each of them every time would make listings slow.
+const parsedRecheck = 30 * time.Second
+
+var parsedFiles = struct {
+	sync.Mutex
+	m map[string]*parsedFile
+}{m: make(map[string]*parsedFile)}
+
+// loadParsed returns what parse makes of the file at p in the served tree, or
+// nil if there is no such file. The file is only parsed again when its
+// modification time or size changes. Files in remote storage are checked for
+// changes at most every parsedRecheck.
+func loadParsed(p string, parse func(io.Reader) (interface{}, error)) (interface{}, error) {
+	now := time.Now()
+	remote := store.LocalPath(p) == ""
+
+	parsedFiles.Lock()
+	pf := parsedFiles.m[p]
+	parsedFiles.Unlock()
+	if pf != nil && remote && now.Sub(pf.checked) < parsedRecheck {
+		return pf.val, pf.err
+	}
+
+	f, err := store.Open(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			parsedFiles.Lock()
+			parsedFiles.m[p] = &parsedFile{size: -1, checked: now}
+			parsedFiles.Unlock()
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if pf != nil && pf.mod.Equal(fi.ModTime()) && pf.size == fi.Size() {
+		checked := *pf
+		checked.checked = now
+		parsedFiles.Lock()
+		parsedFiles.m[p] = &checked
+		parsedFiles.Unlock()
+		return pf.val, pf.err
+	}
+
+	pf = &parsedFile{mod: fi.ModTime(), size: fi.Size(), checked: now}
+	if pf.val, pf.err = parse(f); pf.err != nil {
+		pf.val, pf.err = nil, fmt.Errorf("%s: %v", p, pf.err)
+	}
+
+	parsedFiles.Lock()
+	parsedFiles.m[p] = pf
+	parsedFiles.Unlock()
+	return pf.val, pf.err
+}
+
+func (c *dirConfig) check() error {
+	for _, pat := range c.Hide {
+		if _, err := path.Match(pat, ""); err != nil {
+			return fmt.Errorf("hide %q: %v", pat, err)
+		}
+	}
+	if _, ok := sortCols[c.Sort]; c.Sort != "" && !ok {
+		return fmt.Errorf("unknown sort %q", c.Sort)
+	}
+	if strings.ContainsAny(c.Readme, `/\`) {
+		return errors.New("readme must be a file in the same directory")
+	}
+	return nil
+}
+
+// denied reports whether the directory may not be listed.
+func (c *dirConfig) denied() bool {
+	return c.Deny != nil && *c.Deny
+}
+
+// hidden reports whether the entry called name is left out of listings.
+func (c *dirConfig) hidden(name string) bool {
+	for _, pat := range c.Hide {
+		if ok, _ := path.Match(pat, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sortOrder returns the column to sort by and whether to reverse it, as the s
+// and r query parameters would give them.
+func (c *dirConfig) sortOrder() (string, bool) {
+	return sortCols[c.Sort], c.Reverse != nil && *c.Reverse
+}
+
+// readmeKind is determineReadmeKind, except that only the file named by the
+// readme setting counts if there is one.
+func (c *dirConfig) readmeKind(fi os.FileInfo) int {
+	if c.Readme == "" {
+		return determineReadmeKind(fi)
+	}
+	if fi.IsDir() || fi.Name() != c.Readme {
+		return notReadme
+	}
+	for _, p := range readmePatterns {
+		if strings.EqualFold(path.Ext(fi.Name()), path.Ext(p)) {
+			return markdownReadme
+		}
+	}
+	return plainReadme
+}
+
+// apply returns opts with the directory's settings taken into account.
+func (c *dirConfig) apply(opts options) options {
+	if c.Zip != nil && !*c.Zip {
+		opts.ZipFolderEnable = false
+		opts.ZipFolderEnableRecursive = false
+	}
+	return opts
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// configTree serves files as storedZipTree does, with nothing left over in
+// the cache of parsed files from other trees.
+func configTree(t *testing.T, files map[string]string) (done func()) {
+	_, _, done = storedZipTree(t, files)
+	parsedFiles.Lock()
+	parsedFiles.m = make(map[string]*parsedFile)
+	parsedFiles.Unlock()
+	return done
+}
+
+func TestDirConfigInheritance(t *testing.T) {
+	done := configTree(t, map[string]string{
+		".index.toml": `
+sort = "size"
+hide = ["*.bak"]
+gallery = false
+`,
+		"a/.index.toml": `
+reverse = true
+hide = ["tmp"]
+gallery = true
+readme = "ABOUT.md"
+`,
+		"a/b/.index.toml": `
+sort = "modified"
+deny = true
+zip = false
+`,
+		"a/b/c/x.txt": "",
+		"d/.index.toml": `
+reverse = false
+`,
+		"d/e/x.txt": "",
+	})
+	defer done()
+
+	tests := []struct {
+		dir     string
+		sort    string
+		reverse bool
+		gallery bool
+		deny    bool
+		zip     bool
+		readme  string
+		hide    string
+	}{
+		{"/", "size", false, false, false, true, "", "*.bak"},
+		{"/a", "size", true, true, false, true, "ABOUT.md", "*.bak tmp"},
+		{"/a/b", "modified", true, true, true, false, "ABOUT.md", "*.bak tmp"},
+		{"/a/b/c", "modified", true, true, true, false, "ABOUT.md", "*.bak tmp"},
+		{"/a/b/c/", "modified", true, true, true, false, "ABOUT.md", "*.bak tmp"},
+		{"/d", "size", false, false, false, true, "", "*.bak"},
+		{"/d/e", "size", false, false, false, true, "", "*.bak"},
+		{"/missing", "size", false, false, false, true, "", "*.bak"},
+	}
+	isTrue := func(b *bool, unset bool) bool {
+		if b == nil {
+			return unset
+		}
+		return *b
+	}
+	for _, tt := range tests {
+		c, err := dirConfigFor(tt.dir)
+		if err != nil {
+			t.Errorf("%s: %v", tt.dir, err)
+			continue
+		}
+		if c.Sort != tt.sort {
+			t.Errorf("%s: sort = %q, want %q", tt.dir, c.Sort, tt.sort)
+		}
+		if got := isTrue(c.Reverse, false); got != tt.reverse {
+			t.Errorf("%s: reverse = %v, want %v", tt.dir, got, tt.reverse)
+		}
+		if got := isTrue(c.Gallery, false); got != tt.gallery {
+			t.Errorf("%s: gallery = %v, want %v", tt.dir, got, tt.gallery)
+		}
+		if got := c.denied(); got != tt.deny {
+			t.Errorf("%s: denied = %v, want %v", tt.dir, got, tt.deny)
+		}
+		if got := isTrue(c.Zip, true); got != tt.zip {
+			t.Errorf("%s: zip = %v, want %v", tt.dir, got, tt.zip)
+		}
+		if c.Readme != tt.readme {
+			t.Errorf("%s: readme = %q, want %q", tt.dir, c.Readme, tt.readme)
+		}
+		if got := strings.Join(c.Hide, " "); got != tt.hide {
+			t.Errorf("%s: hide = %q, want %q", tt.dir, got, tt.hide)
+		}
+	}
+
+	// children are worked out from their parent without disturbing it
+	a, err := dirConfigFor("/a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := a.child("/a/b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.denied() || !b.denied() {
+		t.Errorf("child: /a denied = %v, /a/b denied = %v; want false, true", a.denied(), b.denied())
+	}
+	if !a.hidden("x.bak") || !a.hidden("tmp") || a.hidden("tmp2") {
+		t.Errorf("/a hides %q", a.Hide)
+	}
+}
+
+func TestDirConfigErrors(t *testing.T) {
+	tests := []string{
+		`sort = "color"`,
+		`readme = "../README"`,
+		`hide = ["[a-"]`,
+		`colour = "blue"`,
+		`deny = "yes"`,
+		`sort = `,
+	}
+	for _, conf := range tests {
+		done := configTree(t, map[string]string{"a/.index.toml": conf, "a/x.txt": ""})
+		if _, err := dirConfigFor("/a/b"); err == nil {
+			t.Errorf("%q was accepted", conf)
+		}
+		if _, err := dirConfigFor("/"); err != nil {
+			t.Errorf("%q breaks the directory above: %v", conf, err)
+		}
+		done()
+	}
+}
@@ -0,0 +1,437 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"ktkr.us/pkg/airlift/contentdisposition"
+	"ktkr.us/pkg/fmtutil"
+	"ktkr.us/pkg/gas"
+	"ktkr.us/pkg/gas/out"
+)
+
+// newDownload returns the archive of entries from the directory dir in the
+// given format: "tar", "tgz", "zstd", or zip by default.
+func newDownload(dir string, entries []*downloadEntry, format string) gas.Outputter {
+	switch format {
+	case "tar":
+		return &tarball{dir, entries, ""}
+	case "tgz":
+		return &tarball{dir, entries, "gzip"}
+	case "zstd":
+		return &tarball{dir, entries, "zstd"}
+	}
+	return &zipper{dir, entries}
+}
+
+// postIndex handles the two forms a listing can post: uploads, which are
+// multipart, and downloads of selected files, which aren't.
+func postIndex(g *gas.Gas) (int, gas.Outputter) {
+	mt, _, _ := mime.ParseMediaType(g.Request.Header.Get("Content-Type"))
+	if mt == "multipart/form-data" {
+		return postUpload(g)
+	}
+	return postDownload(g)
+}
+
+// postDownload sends the files and directories checked in a listing as one
+// archive. They are named by their paths relative to the listed directory,
+// and may be further down than it, but never outside it.
+func postDownload(g *gas.Gas) (int, gas.Outputter) {
+	user, code, ok := authorize(g, g.Request, g.URL.Path)
+	if !ok {
+		return code, out.HTML(strconv.Itoa(code), nil, "layout")
+	}
+
+	flt, err := filterFor(g.URL.Path)
+	if err != nil {
+		return 500, out.HTML("500", err, "layout")
+	}
+	if flt.conf.denied() {
+		return 403, out.HTML("403", nil, "layout")
+	}
+	opts := flt.conf.apply(optionsFor(g.URL.Path))
+	if !opts.ZipFolderEnable {
+		g.Header().Set("Allow", "GET, HEAD")
+		return 405, out.HTML("405", nil, "layout")
+	}
+
+	if err := g.Request.ParseForm(); err != nil {
+		return 400, out.HTML("400", err, "layout")
+	}
+	names := g.Request.PostForm["f"]
+	if len(names) == 0 {
+		return 400, out.HTML("400", "nothing was selected", "layout")
+	}
+
+	fi, err := statStorage(store, g.URL.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 404, out.HTML("404", err, "layout")
+		}
+		return 500, out.HTML("500", err, "layout")
+	}
+	if !fi.IsDir() {
+		return 400, out.HTML("400", "downloads must be posted to a directory", "layout")
+	}
+
+	entries, err := selectedEntries(g.URL.Path, flt, names, user, opts.ZipFolderEnableRecursive)
+	if err != nil {
+		if err == errBadSelection {
+			return 400, out.HTML("400", err, "layout")
+		}
+		return 500, out.HTML("500", err, "layout")
+	}
+	if err := checkDownloadLimits(entries); err != nil {
+		return 413, out.HTML("413", err, "layout")
+	}
+	return 200, newDownload(g.URL.Path, entries, g.Request.PostForm.Get("format"))
+}
+
+var errBadSelection = errors.New("invalid selection")
+
+// selectedEntries collects the files named by names, relative to dir, whose
+// filter is flt. Checked directories bring everything in them if recursive
+// downloads are allowed, and are refused otherwise. Names that lead outside
+// dir, or to something hidden or off limits, are refused too.
+func selectedEntries(dir string, flt *filter, names []string, user string, recursive bool) ([]*downloadEntry, error) {
+	var (
+		root    = path.Clean("/" + dir)
+		prefix  = strings.TrimSuffix(root, "/") + "/"
+		seen    = make(map[string]bool)
+		entries []*downloadEntry
+	)
+
+	for _, name := range names {
+		p := path.Clean(prefix + name)
+		if !strings.HasPrefix(p, prefix) || !canAccess(user, p) {
+			return nil, errBadSelection
+		}
+
+		fi, err := statStorage(store, p)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, errBadSelection
+			}
+			return nil, err
+		}
+		hidden, err := flt.hiddenBelow(strings.TrimPrefix(p, prefix), fi.IsDir())
+		if err != nil {
+			return nil, err
+		}
+		if hidden {
+			return nil, errBadSelection
+		}
+
+		var found []*downloadEntry
+		if fi.IsDir() {
+			if !recursive {
+				return nil, errBadSelection
+			}
+			if found, err = walk(p, user); err != nil {
+				return nil, err
+			}
+		} else {
+			e, err := newDownloadEntry("", p, fi)
+			if err != nil {
+				return nil, err
+			}
+			found = []*downloadEntry{e}
+		}
+
+		for _, e := range found {
+			if seen[e.Path] {
+				continue
+			}
+			seen[e.Path] = true
+			// named from dir rather than from what was checked, so
+			// things keep their place relative to each other
+			e.Name = strings.TrimSuffix(strings.TrimPrefix(e.Path, prefix), "/")
+			entries = append(entries, e)
+		}
+	}
+
+	sort.Sort(byEntryName(entries))
+	return entries, nil
+}
+
+// downloadTotals returns the number of files in entries and their total size,
+// which is about what an archive of them comes to.
+func downloadTotals(entries []*downloadEntry) (files int, size int64) {
+	for _, e := range entries {
+		if e.Info.IsDir() {
+			continue
+		}
+		files++
+		size += e.Info.Size()
+	}
+	return files, size
+}
+
+// checkDownloadLimits refuses downloads of more than Conf.ZipFolderMaxFiles
+// files or Conf.ZipFolderMaxSize bytes. It must be called before anything is
+// written, while there's still a chance to send an error page.
+func checkDownloadLimits(entries []*downloadEntry) error {
+	return checkDownloadTotals(downloadTotals(entries))
+}
+
+// checkDownloadTotals is checkDownloadLimits for a download of files files
+// coming to size bytes.
+func checkDownloadTotals(files int, size int64) error {
+	switch {
+	case Conf.ZipFolderMaxFiles > 0 && files > Conf.ZipFolderMaxFiles:
+		return fmt.Errorf("download of %d files is more than the limit of %d", files, Conf.ZipFolderMaxFiles)
+	case Conf.ZipFolderMaxSize > 0 && size > Conf.ZipFolderMaxSize:
+		return fmt.Errorf("download of %v is more than the limit of %v", fmtutil.SI(size), fmtutil.SI(Conf.ZipFolderMaxSize))
+	}
+	return nil
+}
+
+// tarball writes a directory download as a tar file, optionally compressed
+// with gzip or zstd. Unlike zip, tar keeps Unix modes, symlinks and
+// directories with their modification times.
+type tarball struct {
+	dir      string
+	entries  []*downloadEntry
+	compress string // "", "gzip" or "zstd"
+}
+
+func (t *tarball) Output(code int, g *gas.Gas) {
+	if gate != nil {
+		gate.Start()
+		defer gate.Done()
+	}
+
+	var (
+		cw                 = &checkedWriter{w: g}
+		w        io.Writer = cw
+		zc       io.Closer
+		ext      = ".tar"
+		ctyp     = "application/x-tar"
+		skipped  []string
+		writeErr error
+	)
+
+	switch t.compress {
+	case "gzip":
+		zw := gzip.NewWriter(cw)
+		w, zc, ext, ctyp = zw, zw, ".tar.gz", "application/gzip"
+	case "zstd":
+		zw, err := zstd.NewWriter(cw)
+		if err != nil {
+			// nothing has been sent yet, so there's still a page to show
+			log.Printf("tarball: %v", err)
+			out.HTML("500", err, "layout").Output(500, g)
+			return
+		}
+		w, zc, ext, ctyp = zw, zw, ".tar.zst", "application/zstd"
+	}
+
+	g.Header().Set("Content-Type", ctyp)
+	contentdisposition.SetFilename(g, filepath.Base(t.dir)+ext)
+	// the outcome is only known at the end, so it's sent after the body
+	g.Header().Set("Trailer", downloadStatusTrailer)
+	g.WriteHeader(code)
+
+	tw := tar.NewWriter(w)
+	for _, e := range t.entries {
+		skip, err := writeTarEntry(tw, e)
+		if err != nil {
+			writeErr = err
+			break
+		}
+		if skip != nil {
+			skipped = append(skipped, skippedLine("tarball", e, skip))
+		}
+	}
+
+	if len(skipped) > 0 && writeErr == nil {
+		msg := downloadErrors(skipped)
+		writeErr = tw.WriteHeader(&tar.Header{
+			Name:     zipErrorsName,
+			Typeflag: tar.TypeReg,
+			Mode:     0644,
+			Size:     int64(len(msg)),
+			ModTime:  time.Now(),
+		})
+		if writeErr == nil {
+			_, writeErr = io.WriteString(tw, msg)
+		}
+	}
+	if err := tw.Close(); writeErr == nil {
+		writeErr = err
+	}
+	if zc != nil {
+		if err := zc.Close(); writeErr == nil {
+			writeErr = err
+		}
+	}
+	if cw.err != nil {
+		writeErr = cw.err
+	}
+
+	setDownloadStatus(g, "tarball", writeErr, len(skipped))
+}
+
+// writeTarEntry adds e to tw. An entry that can't be read is left out, and one
+// that stops part way is padded to the size its header promised; either way
+// skipped says why. err means the archive can't be continued.
+func writeTarEntry(tw *tar.Writer, e *downloadEntry) (skipped, err error) {
+	mode := e.Info.Mode()
+	if !mode.IsRegular() && !mode.IsDir() && mode&os.ModeSymlink == 0 {
+		// devices, sockets and pipes
+		return nil, nil
+	}
+
+	hdr, err := tar.FileInfoHeader(e.Info, e.Link)
+	if err != nil {
+		return err, nil
+	}
+	hdr.Name = e.Name
+	if mode.IsDir() {
+		hdr.Name += "/"
+	}
+
+	if !mode.IsRegular() {
+		return nil, tw.WriteHeader(hdr)
+	}
+
+	// open before writing the header, so that a file that can't be read is
+	// left out rather than breaking the archive
+	f, err := store.Open(e.Path)
+	if err != nil {
+		return err, nil
+	}
+	defer f.Close()
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return nil, err
+	}
+	cr := &checkedReader{r: f}
+	n, err := io.Copy(tw, io.LimitReader(cr, hdr.Size))
+	if err != nil && cr.err == nil {
+		return nil, err
+	}
+	if n < hdr.Size {
+		// the header promised hdr.Size bytes, so a file that shrank or
+		// failed since it was listed is padded with zeros rather than
+		// ending the archive early
+		if _, err := io.CopyN(tw, zeros{}, hdr.Size-n); err != nil {
+			return nil, err
+		}
+		if cr.err == nil {
+			cr.err = errShrunk
+		}
+		return fmt.Errorf("incomplete: %v", cr.err), nil
+	}
+	return nil, nil
+}
+
+// checkedReader remembers the first error reading from r, so that failing to
+// read a file can be told apart from failing to write the archive.
+type checkedReader struct {
+	r   io.Reader
+	err error
+}
+
+func (c *checkedReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if err != nil && err != io.EOF && c.err == nil {
+		c.err = err
+	}
+	return n, err
+}
+
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+// sniffLen is how much of a file is looked at to guess its type, the same as
+// http.DetectContentType.
+const sniffLen = 512
+
+// compressedExts are formats that are compressed already, so deflating them
+// again only costs time.
+var compressedExts = map[string]bool{
+	// images
+	".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".webp": true,
+	".heic": true, ".heif": true, ".avif": true, ".jxl": true,
+	// video
+	".mp4": true, ".m4v": true, ".mkv": true, ".webm": true, ".mov": true,
+	".avi": true, ".wmv": true, ".flv": true, ".ogv": true,
+	// audio
+	".mp3": true, ".m4a": true, ".aac": true, ".ogg": true, ".oga": true,
+	".opus": true, ".flac": true, ".wma": true,
+	// archives and compressed files
+	".zip": true, ".gz": true, ".tgz": true, ".bz2": true, ".tbz": true,
+	".tbz2": true, ".xz": true, ".txz": true, ".zst": true, ".lz": true,
+	".lzma": true, ".lz4": true, ".7z": true, ".rar": true, ".cab": true,
+	".jar": true, ".apk": true, ".deb": true, ".rpm": true, ".dmg": true,
+	// documents that are zip files inside
+	".docx": true, ".xlsx": true, ".pptx": true, ".odt": true, ".ods": true,
+	".odp": true, ".epub": true,
+	// fonts
+	".woff": true, ".woff2": true,
+}
+
+// zipMethod decides whether a file goes into a zip download compressed or
+// stored as is. Files of types that are compressed already are stored, and so
+// is everything over Conf.ZipDeflateMaxSize or at compression level 0. Files
+// with an unfamiliar extension are judged by their first bytes, head.
+func zipMethod(name string, size int64, head []byte) uint16 {
+	if m, ok := zipMethodByName(name, size); ok {
+		return m
+	}
+	return zipMethodBySniff(head)
+}
+
+// zipMethodByName is the part of zipMethod that doesn't need the file's
+// contents. It reports false if they're needed to decide.
+func zipMethodByName(name string, size int64) (uint16, bool) {
+	switch {
+	case Conf.ZipCompressLevel == 0,
+		Conf.ZipDeflateMaxSize > 0 && size > Conf.ZipDeflateMaxSize,
+		compressedExts[strings.ToLower(filepath.Ext(name))]:
+		return zip.Store, true
+	}
+	return 0, false
+}
+
+func zipMethodBySniff(head []byte) uint16 {
+	ctype := http.DetectContentType(head)
+	switch {
+	case ctype == "image/bmp", ctype == "audio/wave", ctype == "audio/aiff":
+		// uncompressed media
+		return zip.Deflate
+	case strings.HasPrefix(ctype, "image/"),
+		strings.HasPrefix(ctype, "video/"),
+		strings.HasPrefix(ctype, "audio/"),
+		strings.HasPrefix(ctype, "font/woff"),
+		ctype == "application/zip",
+		ctype == "application/x-gzip",
+		ctype == "application/x-rar-compressed":
+		return zip.Store
+	}
+	return zip.Deflate
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strings"
+)
+
+// includeGlobs and excludeGlobs are INDEX_INCLUDE and INDEX_EXCLUDE split
+// into their patterns.
+var includeGlobs, excludeGlobs []string
+
+// splitGlobs splits a comma-separated list of glob patterns, checking that
+// each of them is valid.
+func splitGlobs(list string) ([]string, error) {
+	var globs []string
+	for _, g := range strings.Split(list, ",") {
+		if g = strings.TrimSpace(g); g == "" {
+			continue
+		}
+		if _, err := path.Match(g, ""); err != nil {
+			return nil, fmt.Errorf("%q: %v", g, err)
+		}
+		globs = append(globs, g)
+	}
+	return globs, nil
+}
+
+func matchAny(globs []string, name string) bool {
+	for _, g := range globs {
+		if ok, _ := path.Match(g, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// A filter decides which entries of a directory are shown. The same filter is
+// used for listings and their counts, galleries, search results and
+// downloads, so that nothing hidden in one turns up in another. An entry is
+// hidden if it's
+//
+//   - a dotfile, unless INDEX_SHOW_HIDDEN is set
+//   - matched by INDEX_EXCLUDE, or by hide in an .index.toml
+//   - a file not matched by INDEX_INCLUDE, if it's set
+//   - ignored by an ignore file in its directory or one above it
+//
+// The .index.toml and ignore files themselves are always hidden.
+type filter struct {
+	dir   string
+	conf  *dirConfig
+	rules []ignoreRule // from the ignore files in dir and above it
+}
+
+// filterFor returns the filter for the entries of the directory dir.
+func filterFor(dir string) (*filter, error) {
+	f := &filter{conf: new(dirConfig)}
+	for _, d := range ancestors(dir) {
+		if err := f.inherit(d); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// child returns the filter for dir, a directory directly within the one f is
+// for.
+func (f *filter) child(dir string) (*filter, error) {
+	cf := *f
+	if err := cf.inherit(dir); err != nil {
+		return nil, err
+	}
+	return &cf, nil
+}
+
+func (f *filter) inherit(dir string) error {
+	conf, err := f.conf.child(dir)
+	if err != nil {
+		return err
+	}
+	rules, err := loadIgnoreFile(dir)
+	if err != nil {
+		return err
+	}
+	f.dir = path.Clean("/" + dir)
+	f.conf = conf
+	// never append to the parent's backing array, which other children share
+	f.rules = append(f.rules[:len(f.rules):len(f.rules)], rules...)
+	return nil
+}
+
+// hidden reports whether the entry called name is hidden.
+func (f *filter) hidden(name string, isDir bool) bool {
+	switch {
+	case name == dirConfigName || Conf.IgnoreFile != "" && name == Conf.IgnoreFile:
+		return true
+	case strings.HasPrefix(name, ".") && !Conf.ShowHidden:
+		return true
+	case matchAny(excludeGlobs, name) || f.conf.hidden(name):
+		return true
+	case !isDir && len(includeGlobs) > 0 && !matchAny(includeGlobs, name):
+		return true
+	}
+
+	// like in git, the last rule to match wins
+	p := path.Join(f.dir, name)
+	ignored := false
+	for _, r := range f.rules {
+		if r.match(p, isDir) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// hiddenBelow reports whether the entry at rel, a slash-separated path below
+// the directory f is for, is hidden, or is in a directory that is.
+func (f *filter) hiddenBelow(rel string, isDir bool) (bool, error) {
+	elems := strings.Split(rel, "/")
+	for i, elem := range elems {
+		last := i == len(elems)-1
+		if f.hidden(elem, isDir || !last) {
+			return true, nil
+		}
+		if !last {
+			var err error
+			if f, err = f.child(path.Join(f.dir, elem)); err != nil {
+				return false, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// A treeFilter applies filters while walking the tree below a directory.
+// Hidden directories are skipped entirely, and so are directories that deny
+// being listed.
+type treeFilter struct {
+	root string
+	dirs map[string]*filter
+}
+
+func newTreeFilter(root string) (*treeFilter, error) {
+	root = path.Clean("/" + root)
+	f, err := filterFor(root)
+	if err != nil {
+		return nil, err
+	}
+	return &treeFilter{root, map[string]*filter{root: f}}, nil
+}
+
+// hidden reports whether the entry at p is hidden. The tree has to be walked
+// from the top down, as filepath.Walk does.
+func (t *treeFilter) hidden(p string, fi os.FileInfo) bool {
+	p = path.Clean(p)
+	if p == t.root {
+		return false
+	}
+
+	f := t.dirs[path.Dir(p)]
+	if f == nil || f.hidden(path.Base(p), fi.IsDir()) {
+		return true
+	}
+
+	if fi.IsDir() {
+		cf, err := f.child(p)
+		if err != nil {
+			log.Print(err)
+			return true
+		}
+		if cf.conf.denied() {
+			return true
+		}
+		t.dirs[p] = cf
+	}
+	return false
+}
+
+// An ignoreRule is a line of an ignore file, which works like .gitignore.
+type ignoreRule struct {
+	base     string // the directory the ignore file is in
+	pattern  string
+	negate   bool // "!pattern" shows what an earlier rule hid
+	dirOnly  bool // "pattern/" only applies to directories
+	anchored bool // patterns with a slash are relative to base
+}
+
+// loadIgnoreFile returns the rules in dir's ignore file, if it has one.
+func loadIgnoreFile(dir string) ([]ignoreRule, error) {
+	if Conf.IgnoreFile == "" {
+		return nil, nil
+	}
+	dir = path.Clean("/" + dir)
+	v, err := loadParsed(path.Join(dir, Conf.IgnoreFile), func(r io.Reader) (interface{}, error) {
+		return parseIgnoreFile(dir, r)
+	})
+	if v == nil {
+		return nil, err
+	}
+	return v.([]ignoreRule), err
+}
+
+func parseIgnoreFile(dir string, r io.Reader) ([]ignoreRule, error) {
+	var rules []ignoreRule
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimRight(s.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{base: dir}
+		if strings.HasPrefix(line, "!") {
+			rule.negate, line = true, line[1:]
+		} else if strings.HasPrefix(line, `\`) {
+			// \# and \! for names that really start with those
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly, line = true, strings.TrimRight(line, "/")
+		}
+		if strings.Contains(line, "/") {
+			rule.anchored, line = true, strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		for _, elem := range strings.Split(line, "/") {
+			if _, err := path.Match(elem, ""); err != nil {
+				return nil, fmt.Errorf("line %d: %v", n, err)
+			}
+		}
+		rule.pattern = line
+		rules = append(rules, rule)
+	}
+	return rules, s.Err()
+}
+
+func (r ignoreRule) match(p string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	if !r.anchored {
+		ok, _ := path.Match(r.pattern, path.Base(p))
+		return ok
+	}
+	rel := strings.TrimPrefix(p, strings.TrimSuffix(r.base, "/")+"/")
+	return matchElems(strings.Split(r.pattern, "/"), strings.Split(rel, "/"))
+}
+
+// matchElems matches a path against a pattern, both split at slashes. A "**"
+// element matches any number of elements, including none.
+func matchElems(pattern, elems []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(elems); i++ {
+				if matchElems(pattern[1:], elems[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(elems) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], elems[0]); !ok {
+			return false
+		}
+		pattern, elems = pattern[1:], elems[1:]
+	}
+	return len(elems) == 0
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIgnoreRules(t *testing.T) {
+	done := configTree(t, map[string]string{
+		".indexignore": `
+# comments and blank lines are skipped
+
+*.log
+!keep.log
+build/
+/top.txt
+docs/**/draft.md
+\#hash
+\!bang
+`,
+		"sub/.indexignore": `
+!important.log
+/local.txt
+`,
+		"sub/x/a.txt": "",
+	})
+	defer done()
+	oldConf := Conf
+	defer func() { Conf = oldConf }()
+	Conf.IgnoreFile = ".indexignore"
+
+	tests := []struct {
+		path   string
+		isDir  bool
+		hidden bool
+	}{
+		// unanchored patterns match the name anywhere below
+		{"a.log", false, true},
+		{"sub/x/a.log", false, true},
+		{"a.log.txt", false, false},
+
+		// the last rule to match wins, and later files come later
+		{"keep.log", false, false},
+		{"sub/x/keep.log", false, false},
+		{"important.log", false, true},
+		{"sub/important.log", false, false},
+		{"sub/x/important.log", false, false},
+
+		// a trailing slash only matches directories, and what's in them is
+		// hidden with them
+		{"build", true, true},
+		{"build", false, false},
+		{"sub/build", true, true},
+		{"sub/build/out.txt", false, true},
+
+		// a leading slash anchors to the ignore file's directory
+		{"top.txt", false, true},
+		{"sub/top.txt", false, false},
+		{"sub/local.txt", false, true},
+		{"local.txt", false, false},
+		{"sub/x/local.txt", false, false},
+
+		// ** matches any number of directories, none included; a slash in
+		// the middle anchors too
+		{"docs/draft.md", false, true},
+		{"docs/a/draft.md", false, true},
+		{"docs/a/b/draft.md", false, true},
+		{"docs/a/final.md", false, false},
+		{"other/docs/draft.md", false, false},
+
+		// escaped comments and negations
+		{"#hash", false, true},
+		{"!bang", false, true},
+		{"bang", false, false},
+
+		// the config files themselves, and dotfiles
+		{".indexignore", false, true},
+		{"sub/.indexignore", false, true},
+		{".index.toml", false, true},
+		{".git", true, true},
+		{"sub/.git/config", false, true},
+	}
+	root, err := filterFor("/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		got, err := root.hiddenBelow(tt.path, tt.isDir)
+		if err != nil {
+			t.Errorf("%s: %v", tt.path, err)
+			continue
+		}
+		if got != tt.hidden {
+			t.Errorf("%s (dir %v): hidden = %v, want %v", tt.path, tt.isDir, got, tt.hidden)
+		}
+	}
+}
+
+func TestIgnoreFileErrors(t *testing.T) {
+	for _, rules := range []string{"[a-", "docs/[/x", "!a\nb/[\n"} {
+		if _, err := parseIgnoreFile("/", strings.NewReader(rules)); err == nil {
+			t.Errorf("%q was accepted", rules)
+		}
+	}
+}
+
+func TestMatchElems(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"a/b", "a/b", true},
+		{"a/b", "a/b/c", false},
+		{"a/*", "a/b", true},
+		{"a/*", "a", false},
+		{"**/b", "b", true},
+		{"**/b", "x/y/b", true},
+		{"a/**", "a/x/y", true},
+		{"a/**", "a", true},
+		{"a/**/b/**/c", "a/b/c", true},
+		{"a/**/b/**/c", "a/x/b/y/z/c", true},
+		{"a/**/b/**/c", "a/x/c", false},
+	}
+	for _, tt := range tests {
+		if got := matchElems(strings.Split(tt.pattern, "/"), strings.Split(tt.path, "/")); got != tt.want {
+			t.Errorf("matchElems(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"archive/zip"
-	"image/jpeg"
+	"bufio"
+	"compress/flate"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
@@ -14,14 +16,14 @@ import (
 	"os/user"
 	"path"
 	"path/filepath"
+	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"go4.org/syncutil"
 
-	"golang.org/x/image/draw"
-
 	"ktkr.us/pkg/airlift/contentdisposition"
 	"ktkr.us/pkg/airlift/thumb"
 	"ktkr.us/pkg/fmtutil"
@@ -33,27 +35,67 @@ import (
 
 //go:generate bindata -skip=*.sw[nop] static templates
 
-const (
-	thumbWidth  = 150
-	thumbHeight = 100
-)
-
 var Conf struct {
 	Root                     string `default:"."`
 	ThumbDir                 string
-	ThumbEnable              bool          `default:"true"`
-	GalleryImages            int           `default:"25"`
-	ZipFolderEnable          bool          `default:"false"` // enable download directory as zip
-	ZipFolderEnableRecursive bool          `default:"false"` // enable download directory recursively as zip
-	ZipFolderMaxConcurrency  int           `default:"0"`     // absolutely limit global number of concurrent zippers
-	FileListShowModes        bool          `default:"true"`  // show file modes (i.e. drwxrwxrwx)
-	ResourceDir              string        // location of static assets on disk
-	IdleTimeout              time.Duration `default:"120m"` // idle connection timeout
+	ThumbEnable              bool   `default:"true"`
+	ThumbSize                string `default:"small"`    // size of gallery thumbnails
+	ThumbFfmpeg              string `default:"ffmpeg"`   // ffmpeg binary for video thumbnails, "" for none
+	ThumbPdftoppm            string `default:"pdftoppm"` // pdftoppm binary for PDF thumbnails, "" for none
+	ThumbCommandsFile        string // commands that rasterize other formats for thumbnails
+	ThumbCwebp               string `default:"cwebp"`   // cwebp binary for WebP thumbnails, "" for none
+	ThumbAvifenc             string `default:"avifenc"` // avifenc binary for AVIF thumbnails, "" for none
+	ThumbWarm                bool   `default:"false"`   // make all gallery thumbnails in the background at startup
+	ThumbWarmWorkers         int    `default:"0"`       // thumbnails made at once by warming and prefetching, 0 for one per CPU
+	ThumbPrefetch            bool   `default:"false"`   // make the next gallery page's thumbnails in the background
+	ThumbToolMaxConcurrency  int    `default:"0"`       // external thumbnail programs run at once, 0 for one per CPU
+	GalleryImages            int    `default:"25"`
+	ZipFolderEnable          bool   `default:"false"` // enable download directory as zip
+	ZipFolderEnableRecursive bool   `default:"false"` // enable download directory recursively as zip
+	ZipFolderMaxConcurrency  int    `default:"0"`     // absolutely limit global number of concurrent zippers
+	ZipCompressLevel         int    `default:"-1"`    // deflate level for zip downloads, 0 to store everything
+	ZipDeflateMaxSize        int64  `default:"0"`     // store files larger than this in zips instead of compressing them
+	ZipFolderMaxSize         int64  `default:"0"`     // refuse directory downloads with more bytes than this
+	ZipFolderMaxFiles        int    `default:"0"`     // refuse directory downloads with more files than this
+	ShowHidden               bool   `default:"false"` // list dotfiles
+	Include                  string // only list files matching these comma-separated globs
+	Exclude                  string // never list names matching these comma-separated globs
+	IgnoreFile               string `default:".indexignore"` // gitignore-style file of names to hide
+	Symlinks                 string `default:"follow"`       // follow symlinks: "follow", "within" the root, or "never"
+	FileListShowModes        bool   `default:"true"`         // show file modes (i.e. drwxrwxrwx)
+	ResourceDir              string // location of static assets on disk
+	AuthPasswdFile           string // htpasswd-style user:hash file
+	AuthTokenFile            string // "token user" lines accepted as bearer tokens
+	AuthRulesFile            string // path prefix → allowed users and groups
+	AuthRealm                string `default:"index"`
+	UploadEnable             bool   `default:"false"`      // accept uploads with POST and PUT
+	UploadMaxSize            int64  `default:"1073741824"` // largest accepted request body in bytes
+	DAVEnable                bool   `default:"false"`      // serve the tree over WebDAV too
+	DAVPrefix                string `default:"/dav"`       // where to mount the WebDAV handler
+	DAVWrite                 bool   `default:"false"`      // allow WebDAV methods that change things
+	S3Endpoint               string // host[:port] of an S3-compatible object store
+	S3Bucket                 string // serve this bucket instead of Root
+	S3Prefix                 string // key prefix within the bucket to serve
+	S3AccessKey              string
+	S3SecretKey              string
+	S3Secure                 bool          `default:"true"` // connect to S3Endpoint over HTTPS
+	TLSCert                  string        // serve HTTPS with this certificate...
+	TLSKey                   string        // ...and key
+	TLSAddr                  string        `default:":443"`
+	TLSRedirect              bool          `default:"false"` // redirect plain HTTP requests to HTTPS
+	IdleTimeout              time.Duration `default:"120m"`  // idle connection timeout
+	CatalogEnable            bool          `default:"false"` // keep an in-memory catalog of every path under Root
+	CatalogFile              string        // where to persist the catalog between runs
+	CatalogRescan            time.Duration `default:"6h"`    // how often to rebuild the catalog from scratch
+	SearchMaxResults         int           `default:"500"`   // stop searching after this many matches
+	SearchTimeout            time.Duration `default:"5s"`    // stop searching after this long
+	ArchiveBrowse            bool          `default:"false"` // list the contents of zip and tar files like directories
+	MountsFile               string        // serve several directories under their own names instead of Root
 }
 
 var (
-	cache *thumb.Cache
-	gate  *syncutil.Gate
+	gate     *syncutil.Gate
+	toolGate *syncutil.Gate // external thumbnail programs
 )
 
 func init() {
@@ -97,39 +139,139 @@ func main() {
 		log.Fatal(err)
 	}
 
+	if err := loadAuth(); err != nil {
+		log.Fatal(err)
+	}
+	onHangup("auth", loadAuth)
+
+	store, err = newStorage()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !isLocal(store) {
+		// these all need a real directory to work with
+		if Conf.CatalogEnable || Conf.UploadEnable || Conf.DAVEnable {
+			log.Print("catalog, uploads and WebDAV are only available with local storage, disabling them")
+		}
+		Conf.CatalogEnable = false
+		Conf.UploadEnable = false
+		Conf.DAVEnable = false
+	}
+	if Conf.ArchiveBrowse {
+		store = newArchiveStorage(store)
+	}
+
 	if Conf.ThumbEnable {
 		if Conf.ThumbDir == "" {
 			Conf.ThumbDir = filepath.Join(u.HomeDir, ".thumbs")
 		}
 
-		enc := thumb.JPEGEncoder{&jpeg.Options{90}}
-		cache, err = thumb.NewCache(Conf.ThumbDir, enc, FSStore{}, draw.ApproxBiLinear)
-		if err != nil {
+		if _, ok := thumbSizes[Conf.ThumbSize+"2x"]; !ok {
+			log.Fatalf("INDEX_THUMB_SIZE must be small, medium or large, not %q", Conf.ThumbSize)
+		}
+
+		n := Conf.ThumbToolMaxConcurrency
+		if n <= 0 {
+			n = runtime.NumCPU()
+		}
+		toolGate = syncutil.NewGate(n)
+
+		if err := setupThumbFormats(); err != nil {
+			log.Fatal(err)
+		}
+		if err := setupRasterizers(); err != nil {
 			log.Fatal(err)
 		}
-		go cache.Serve()
+	}
+
+	if Conf.ZipCompressLevel < flate.DefaultCompression || Conf.ZipCompressLevel > flate.BestCompression {
+		log.Fatalf("INDEX_ZIP_COMPRESS_LEVEL must be between -1 and 9, not %d", Conf.ZipCompressLevel)
+	}
+
+	switch Conf.Symlinks {
+	case symlinksFollow, symlinksWithin, symlinksNever:
+	default:
+		log.Fatalf("INDEX_SYMLINKS must be %q, %q or %q, not %q", symlinksFollow, symlinksWithin, symlinksNever, Conf.Symlinks)
+	}
+
+	if includeGlobs, err = splitGlobs(Conf.Include); err != nil {
+		log.Fatalf("INDEX_INCLUDE: %v", err)
+	}
+	if excludeGlobs, err = splitGlobs(Conf.Exclude); err != nil {
+		log.Fatalf("INDEX_EXCLUDE: %v", err)
 	}
 
 	if Conf.ZipFolderMaxConcurrency > 0 {
 		gate = syncutil.NewGate(Conf.ZipFolderMaxConcurrency)
 	}
 
+	// warming walks the tree itself, and has no use for a catalog that
+	// would only start scanning the same tree
+	if len(os.Args) > 1 && os.Args[1] == "warm" {
+		if !Conf.ThumbEnable {
+			log.Fatal("thumbnails are disabled, there's nothing to warm")
+		}
+		if err := warmThumbs(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if Conf.CatalogEnable {
+		if mounts == nil {
+			cat = newCatalog(store.LocalPath("/"), Conf.CatalogFile)
+			go cat.run()
+		}
+		for _, m := range mounts {
+			var file string
+			if Conf.CatalogFile != "" {
+				file = Conf.CatalogFile + "." + m.name
+			}
+			m.cat = newCatalog(m.root, file)
+			go m.cat.run()
+		}
+	}
+
+	if Conf.ThumbEnable && Conf.ThumbWarm {
+		go func() {
+			if err := warmThumbs(); err != nil {
+				log.Printf("warm: %v", err)
+			}
+		}()
+	}
+	if Conf.ThumbEnable && Conf.ThumbPrefetch {
+		startPrefetch()
+	}
+
 	r.Get("{path}", getIndex)
-	log.Fatal(r.Ignition())
+	r.Post("{path}", postIndex)
+	r.Put("{path}", putUpload)
+
+	var h http.Handler = r
+	if Conf.DAVEnable {
+		h = withDAV(h, newDAVHandler())
+	}
+	log.Fatal(serve(r, h))
 }
 
 type FileEntry struct {
 	Component
-	Size       fmtutil.SI
-	IsDir      bool
-	IsLink     bool
-	Mod        time.Time
-	NumEntries int
-	FileMode   os.FileMode
+	Size       fmtutil.SI  `json:"size"`
+	IsDir      bool        `json:"is_dir"`
+	IsLink     bool        `json:"is_link"`
+	Target     string      `json:"target,omitempty"`  // where a symlink points
+	Broken     bool        `json:"broken,omitempty"`  // a symlink to nothing
+	Refused    bool        `json:"refused,omitempty"` // a symlink INDEX_SYMLINKS doesn't follow
+	Error      string      `json:"error,omitempty"`   // why the entry can't be opened or read
+	Mod        time.Time   `json:"mod"`
+	NumEntries int         `json:"num_entries,omitempty"`
+	FileMode   os.FileMode `json:"mode"`
+	Browse     string      `json:"browse,omitempty"` // where to list an archive's contents
 }
 
 func getIndex(g *gas.Gas) (int, gas.Outputter) {
-	if g.URL.Path != "/" && strings.HasSuffix(g.URL.Path, "/") {
+	// a trailing slash after an archive is how its contents are asked for
+	if g.URL.Path != "/" && strings.HasSuffix(g.URL.Path, "/") && !isArchive(path.Base(g.URL.Path)) {
 		newpath := strings.TrimSuffix(g.URL.Path, "/")
 		if g.URL.RawQuery != "" {
 			newpath += "?" + g.URL.RawQuery
@@ -137,21 +279,35 @@ func getIndex(g *gas.Gas) (int, gas.Outputter) {
 		return 303, out.Redirect(newpath)
 	}
 
+	user, code, ok := authorize(g, g.Request, g.URL.Path)
+	if !ok {
+		return code, out.HTML(strconv.Itoa(code), nil, "layout")
+	}
+
 	var form struct {
 		Zip         bool   `form:"zip"`
+		Tar         bool   `form:"tar"`
+		Tgz         bool   `form:"tgz"`
+		Zstd        bool   `form:"zstd"`
 		Recursive   bool   `form:"rec"`
 		SortCol     string `form:"s"`
 		SortRev     bool   `form:"r"`
 		GalleryPage int    `form:"p"`
-		Thumb       bool   `form:"t"`
+		Thumb       string `form:"t"`
+		Format      string `form:"format"`
+		Query       string `form:"q"`
 	}
 	g.UnmarshalForm(&form)
 
-	dir := http.Dir(Conf.Root)
+	opts := optionsFor(g.URL.Path)
+
+	dir := store
 	f, err := dir.Open(g.URL.Path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return 404, out.HTML("404", err, "layout")
+		} else if isSymlinkErr(err) {
+			return 403, out.HTML("403", err, "layout")
 		} else {
 			return 500, out.HTML("500", err, "layout")
 		}
@@ -162,23 +318,54 @@ func getIndex(g *gas.Gas) (int, gas.Outputter) {
 		return 500, out.HTML("500", err, "layout")
 	}
 
-	if fi.IsDir() && form.Zip && Conf.ZipFolderEnable {
+	var (
+		flt *filter
+		dc  *dirConfig
+	)
+	if fi.IsDir() {
+		if flt, err = filterFor(g.URL.Path); err != nil {
+			return 500, out.HTML("500", err, "layout")
+		}
+		dc = flt.conf
+		if dc.denied() {
+			return 403, out.HTML("403", nil, "layout")
+		}
+		opts = dc.apply(opts)
+	}
+
+	if fi.IsDir() && (form.Zip || form.Tar || form.Tgz || form.Zstd) && opts.ZipFolderEnable {
 		var (
-			fhs []*zip.FileHeader
-			err error
+			entries []*downloadEntry
+			err     error
 		)
 
-		if form.Recursive && Conf.ZipFolderEnableRecursive {
-			fhs, err = walk(g.URL.Path)
+		if form.Recursive && opts.ZipFolderEnableRecursive {
+			entries, err = walk(g.URL.Path, user)
 		} else {
-			fhs, err = readdirnames(g.URL.Path)
+			entries, err = readdirnames(g.URL.Path, user)
 		}
 
 		if err != nil {
 			return 500, out.HTML("500", err, "layout")
 		}
+		if err := checkDownloadLimits(entries); err != nil {
+			return 413, out.HTML("413", err, "layout")
+		}
+
+		var format string
+		switch {
+		case form.Tar:
+			format = "tar"
+		case form.Tgz:
+			format = "tgz"
+		case form.Zstd:
+			format = "zstd"
+		}
+		return 200, newDownload(g.URL.Path, entries, format)
+	}
 
-		return 200, &zipper{g.URL.Path, fhs}
+	if fi.IsDir() && form.Query != "" {
+		return searchIndex(g, user, form.Query, form.SortCol, form.SortRev, form.Format)
 	}
 
 	base := strings.ToLower(filepath.Base(g.URL.Path))
@@ -189,14 +376,25 @@ func getIndex(g *gas.Gas) (int, gas.Outputter) {
 
 	if !fi.IsDir() {
 		// file was requested
-		if Conf.ThumbEnable && form.Thumb && thumb.FormatSupported(filepath.Ext(fi.Name())) {
-			p := filepath.Join(Conf.Root, g.URL.Path)
-			thumbPath := cache.Get(p, thumbWidth, thumbHeight)
-			// serve original image if we can't thumbnail
-			if thumbPath != "" {
+		if Conf.ThumbEnable && form.Thumb != "" && thumbSupported(fi.Name()) {
+			size, ok := thumbSizes[form.Thumb]
+			if !ok {
+				return 400, out.HTML("400", fmt.Sprintf("unknown thumbnail size %q", form.Thumb), "layout")
+			}
+			tf := thumbFormatFor(g.Request, fi.Name())
+			thumbPath, err := thumbnail(g.URL.Path, fi, size, tf)
+			g.Header().Add("Vary", "Accept")
+			if err == nil {
+				g.Header().Set("Content-Type", tf.ctype)
 				http.ServeFile(g, g.Request, thumbPath)
 				return g.Stop()
 			}
+			log.Printf("thumbnail %s: %v", g.URL.Path, err)
+			// serve original image if we can't thumbnail, but not a
+			// whole video
+			if !thumb.FormatSupported(filepath.Ext(fi.Name())) {
+				return 404, out.HTML("404", err, "layout")
+			}
 		}
 		log.Print(g.Request.Header.Get("Range"))
 		t := time.Now()
@@ -207,7 +405,7 @@ func getIndex(g *gas.Gas) (int, gas.Outputter) {
 
 	// directory listing requested
 
-	fis, err := f.Readdir(-1)
+	fis, err := readDir(g.URL.Path)
 	if err != nil {
 		return 500, out.HTML("500", err, "layout")
 	}
@@ -218,33 +416,52 @@ func getIndex(g *gas.Gas) (int, gas.Outputter) {
 		readmeKind    int
 		imageFiles    []*FileEntry
 		nonImageFiles []*FileEntry
+
+		// what downloading the directory would come to, without
+		// subdirectories
+		downloadFiles int
+		downloadBytes int64
 	)
 
 	for _, fi := range fis {
-		if strings.HasPrefix(fi.Name(), ".") {
-			continue
-		}
-
 		var (
-			path        = filepath.Join(g.URL.Path, fi.Name())
-			isLink      = fi.Mode()&os.ModeSymlink != 0
-			currentFile http.File
+			info    = fi // as readDir gave it, where links are links
+			path    = filepath.Join(g.URL.Path, fi.Name())
+			isLink  = fi.Mode()&os.ModeSymlink != 0
+			target  string
+			broken  bool
+			refused bool
+			entErr  error // what went wrong looking at the entry, if anything
 		)
 
+		if !canAccess(user, path) {
+			continue
+		}
+
 		if isLink {
-			currentFile, err = dir.Open(path)
-			if err != nil {
-				return 500, out.HTML("500", err, "layout")
-			}
-			fi, err = currentFile.Stat()
-			if err != nil {
-				return 500, out.HTML("500", err, "layout")
+			// links that can't be followed are listed as they are, with
+			// the reason, rather than failing the whole listing
+			target = linkTarget(path)
+			if lfi, err := statStorage(dir, path); err == nil {
+				fi = lfi
+			} else {
+				refused = isSymlinkErr(err)
+				broken = !refused && os.IsNotExist(err)
+				entErr = err
 			}
 		}
 
+		if flt.hidden(fi.Name(), fi.IsDir()) {
+			continue
+		}
+		if !info.IsDir() {
+			downloadFiles++
+			downloadBytes += info.Size()
+		}
+
 		// only pick first one encountered
-		if readmeKind == notReadme {
-			if readmeKind = determineReadmeKind(fi); readmeKind != notReadme {
+		if readmeKind == notReadme && entErr == nil {
+			if readmeKind = dc.readmeKind(fi); readmeKind != notReadme {
 				f, err := dir.Open(path)
 				if err != nil {
 					readme = []byte(err.Error())
@@ -265,117 +482,123 @@ func getIndex(g *gas.Gas) (int, gas.Outputter) {
 			Size:     fmtutil.SI(fi.Size()),
 			IsDir:    fi.IsDir(),
 			IsLink:   isLink,
+			Target:   target,
+			Broken:   broken,
+			Refused:  refused,
 			Mod:      fi.ModTime(),
 			FileMode: fi.Mode(),
 		}
 
+		if fi.Mode().IsRegular() && isArchive(fi.Name()) {
+			e.Browse = e.Path + "/"
+		}
+
 		if fi.IsDir() {
-			if currentFile == nil {
-				currentFile, err = dir.Open(path)
-				if err != nil {
-					return 500, out.HTML("500", err, "layout")
-				}
+			cf, err := flt.child(path)
+			if err != nil {
+				log.Print(err)
+				cf = flt
 			}
-			fis, err = currentFile.Readdir(-1)
+			fis, err = readDir(path)
 			if err != nil {
 				log.Print(err)
+				entErr = err
 			} else {
 				for _, contained := range fis {
-					if !strings.HasPrefix(contained.Name(), ".") {
+					if !cf.hidden(contained.Name(), contained.IsDir()) && canAccess(user, filepath.Join(path, contained.Name())) {
 						e.NumEntries++
 					}
 				}
 			}
 		}
 
+		if entErr != nil {
+			e.Error = entryError(entErr, isLink)
+		}
+
 		entries = append(entries, e)
-		if thumb.FormatSupported(filepath.Ext(fi.Name())) {
+		// a broken link has nothing to make a thumbnail of
+		if thumbSupported(fi.Name()) && entErr == nil {
 			imageFiles = append(imageFiles, e)
 		} else {
 			nonImageFiles = append(nonImageFiles, e)
 		}
 	}
 
-	if form.SortCol != "" {
-		var sorter sort.Interface
-		s := true
-
-		switch form.SortCol {
-		case "n":
-			sorter = byName(entries)
-		case "s":
-			sorter = bySize(entries)
-		case "m":
-			sorter = byModTime(entries)
-		default:
-			s = false
-		}
-
-		if s {
-			if form.SortRev {
-				sorter = sort.Reverse(sorter)
-			}
-
-			sort.Stable(sorter)
-		}
+	if form.SortCol == "" {
+		form.SortCol, form.SortRev = dc.sortOrder()
 	}
+	sortEntries(entries, form.SortCol, form.SortRev)
 
 	var (
-		relPath      = strings.TrimPrefix(g.URL.Path, "/")
-		components   []Component
+		components   = breadcrumbs(g.URL.Path)
 		showGallery  = len(imageFiles) > len(entries)/2
 		galleryPages int
 	)
-
-	if relPath == "" {
-		components = []Component{{"/", "/"}}
-	} else {
-		parts := strings.Split(relPath, string([]rune{filepath.Separator}))
-		components = make([]Component, len(parts)+1)
-		components[0] = Component{"/", "/"}
-		for i, p := range parts {
-			components[i+1] = Component{p + "/", "/" + filepath.Join(parts[:i+1]...)}
-		}
+	if dc.Gallery != nil {
+		showGallery = *dc.Gallery && len(imageFiles) > 0
 	}
 
 	if showGallery {
 		entries = nonImageFiles
-		galleryPages = int(math.Ceil(float64(len(imageFiles)) / float64(Conf.GalleryImages)))
+		galleryPages = int(math.Ceil(float64(len(imageFiles)) / float64(opts.GalleryImages)))
 
 		if form.GalleryPage < 1 {
 			form.GalleryPage = 1
 		}
-		off := (form.GalleryPage - 1) * Conf.GalleryImages
+		if prefetchQueue != nil && form.GalleryPage < galleryPages {
+			// the next page is likely to be looked at soon
+			next := imageFiles[form.GalleryPage*opts.GalleryImages:]
+			if len(next) > opts.GalleryImages {
+				next = next[:opts.GalleryImages]
+			}
+			for _, e := range next {
+				tf := thumbFormatFor(g.Request, e.Name)
+				if !prefetchThumbs(galleryThumbJobs(path.Join(g.URL.Path, e.Name), []*thumbFormat{tf})) {
+					break
+				}
+			}
+		}
+
+		off := (form.GalleryPage - 1) * opts.GalleryImages
 		if off < len(imageFiles) {
-			if len(imageFiles)-off < Conf.GalleryImages {
+			if len(imageFiles)-off < opts.GalleryImages {
 				imageFiles = imageFiles[off:]
 			} else {
-				imageFiles = imageFiles[off : off+Conf.GalleryImages]
+				imageFiles = imageFiles[off : off+opts.GalleryImages]
 			}
 		}
+	} else {
+		// images are already in the regular entry list
+		imageFiles = nil
 	}
 
 	data := &struct {
-		Components   []Component
-		UpDir        string
-		Entries      []*FileEntry
-		ImageFiles   []*FileEntry
-		Readme       []byte
-		PlainReadme  bool
-		SortCol      string
-		SortRev      bool
-		Gallery      bool
-		GalleryPage  int
-		NextPage     int
-		PrevPage     int
-		GalleryPages int
-		Config       interface{}
+		Components   []Component  `json:"components"`
+		UpDir        string       `json:"up_dir"`
+		Entries      []*FileEntry `json:"entries"`
+		ImageFiles   []*FileEntry `json:"image_files,omitempty"`
+		Readme       []byte       `json:"-"`
+		ReadmeText   string       `json:"readme,omitempty"`
+		PlainReadme  bool         `json:"plain_readme"`
+		SortCol      string       `json:"sort_col"`
+		SortRev      bool         `json:"sort_rev"`
+		Gallery      bool         `json:"gallery"`
+		GalleryPage  int          `json:"gallery_page"`
+		NextPage     int          `json:"-"`
+		PrevPage     int          `json:"-"`
+		GalleryPages int          `json:"gallery_pages"`
+		DownloadSize fmtutil.SI   `json:"download_size,omitempty"`
+		TooLarge     bool         `json:"-"`
+		Config       interface{}  `json:"-"`
+		Options      options      `json:"-"`
 	}{
 		components,
-		path.Dir(g.URL.Path),
+		browsePath(path.Dir(path.Clean(g.URL.Path))),
 		entries,
 		imageFiles,
 		readme,
+		string(readme),
 		readmeKind == plainReadme,
 		form.SortCol,
 		form.SortRev,
@@ -384,74 +607,120 @@ func getIndex(g *gas.Gas) (int, gas.Outputter) {
 		form.GalleryPage + 1,
 		form.GalleryPage - 1,
 		galleryPages,
+		fmtutil.SI(downloadBytes),
+		checkDownloadTotals(downloadFiles, downloadBytes) != nil,
 		&Conf,
+		opts,
+	}
+
+	// the same listing is served as HTML or JSON depending on what was asked
+	// for, so that scripts see exactly what the browser sees
+	g.Header().Add("Vary", "Accept")
+	if wantJSON(g, form.Format) {
+		return 200, out.JSON(data)
 	}
 
 	return 200, out.HTML("index", data, "layout")
 }
 
-func readdirnames(root string) ([]*zip.FileHeader, error) {
-	f, err := http.Dir(Conf.Root).Open(root)
+// A downloadEntry is a file, directory or symlink to put in an archive of a
+// directory being downloaded.
+type downloadEntry struct {
+	Name string      // path in the archive
+	Path string      // path in the served tree
+	Info os.FileInfo // as from lstat, so symlinks are links
+	Link string      // symlink target
+}
+
+func newDownloadEntry(name, p string, fi os.FileInfo) (*downloadEntry, error) {
+	e := &downloadEntry{Name: name, Path: p, Info: fi}
+	if fi.Mode()&os.ModeSymlink != 0 {
+		link, err := readLink(p)
+		if err != nil {
+			return nil, err
+		}
+		e.Link = link
+	}
+	return e, nil
+}
+
+func readdirnames(root, user string) ([]*downloadEntry, error) {
+	names, err := readDir(root)
 	if err != nil {
 		return nil, err
 	}
-
-	names, err := f.Readdir(0)
+	flt, err := filterFor(root)
 	if err != nil {
 		return nil, err
 	}
 
 	var (
-		fhs  = make([]*zip.FileHeader, 0, len(names))
-		base = filepath.Base(root)
+		entries = make([]*downloadEntry, 0, len(names))
+		base    = filepath.Base(root)
 	)
 
 	for _, fi := range names {
-		if !fi.Mode().IsRegular() {
+		p := path.Join(root, fi.Name())
+		if fi.IsDir() || flt.hidden(fi.Name(), false) || !canAccess(user, p) {
 			continue
 		}
-		fh, err := zip.FileInfoHeader(fi)
+		name := filepath.Base(fi.Name())
+		e, err := newDownloadEntry(strings.TrimPrefix(filepath.Join(base, name), string([]rune{filepath.Separator})), p, fi)
 		if err != nil {
-			return nil, err
+			log.Printf("download: %v", err)
+			continue
 		}
-		name := filepath.Base(fi.Name())
-		fh.Name = strings.TrimPrefix(filepath.Join(base, name), string([]rune{filepath.Separator}))
-		fhs = append(fhs, fh)
+		entries = append(entries, e)
 	}
 
-	return fhs, nil
+	sort.Sort(byEntryName(entries))
+	return entries, nil
 }
 
-func walk(root string) ([]*zip.FileHeader, error) {
-	fhs := make([]*zip.FileHeader, 0)
-	root = filepath.Join(Conf.Root, root)
-	dir := filepath.Dir(root)
+func walk(root, user string) ([]*downloadEntry, error) {
+	entries := make([]*downloadEntry, 0)
+	dir := path.Dir(path.Clean("/" + root))
+	tf, err := newTreeFilter(root)
+	if err != nil {
+		return nil, err
+	}
 
-	err := filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+	err = walkTree(root, func(p string, fi os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		if !fi.Mode().IsRegular() {
+		if tf.hidden(p, fi) || !canAccess(user, p) {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
 			return nil
 		}
-		fh, err := zip.FileInfoHeader(fi)
+		// an archive being browsed has a trailing slash
+		name := strings.TrimSuffix(strings.TrimPrefix(p, strings.TrimSuffix(dir, "/")+"/"), "/")
+		e, err := newDownloadEntry(name, p, fi)
 		if err != nil {
-			return err
+			log.Printf("download: %v", err)
+			return nil
 		}
-		fh.Name, _ = filepath.Rel(dir, path)
-		fhs = append(fhs, fh)
+		entries = append(entries, e)
 		return nil
 	})
 
 	if err != nil {
 		return nil, err
 	}
-	return fhs, nil
+	return entries, nil
 }
 
+type byEntryName []*downloadEntry
+
+func (b byEntryName) Len() int           { return len(b) }
+func (b byEntryName) Less(i, j int) bool { return b[i].Name < b[j].Name }
+func (b byEntryName) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+
 type zipper struct {
-	dir string
-	fhs []*zip.FileHeader
+	dir     string
+	entries []*downloadEntry
 }
 
 func (z *zipper) Output(code int, g *gas.Gas) {
@@ -460,39 +729,134 @@ func (z *zipper) Output(code int, g *gas.Gas) {
 		defer gate.Done()
 	}
 
-	dir := filepath.Dir(z.dir)
+	g.Header().Set("Content-Type", "application/zip")
+	contentdisposition.SetFilename(g, filepath.Base(z.dir)+".zip")
 
-	names := make([]string, len(z.fhs))
-	for i, fh := range z.fhs {
-		names[i] = fh.Name
+	if sz := planStoredZip(z.entries); sz != nil {
+		// the whole layout is known, so the download can have a length and
+		// be resumed
+		g.Header().Set("Etag", sz.etag)
+		http.ServeContent(g, g.Request, filepath.Base(z.dir)+".zip", time.Time{}, sz.reader())
+		return
 	}
 
-	g.Header().Set("Content-Type", "application/zip")
-	contentdisposition.SetFilename(g, filepath.Base(z.dir)+".zip")
+	// the outcome is only known at the end, so it's sent after the body
+	g.Header().Set("Trailer", downloadStatusTrailer)
 	g.WriteHeader(code)
 
-	zw := zip.NewWriter(g)
-	defer zw.Close()
-	for _, fh := range z.fhs {
-		path := filepath.Join(Conf.Root, dir, fh.Name)
-		f, err := os.Open(path)
+	var (
+		cw      = &checkedWriter{w: g}
+		zw      = zip.NewWriter(cw)
+		skipped []string
+	)
+	zw.RegisterCompressor(zip.Deflate, func(w io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(w, Conf.ZipCompressLevel)
+	})
+	skip := func(e *downloadEntry, err error) {
+		skipped = append(skipped, skippedLine("zipper", e, err))
+	}
+
+	for _, e := range z.entries {
+		// directories are implied by the files in them, and zip has no
+		// portable way to store symlinks
+		if !e.Info.Mode().IsRegular() {
+			continue
+		}
+		fh, err := zip.FileInfoHeader(e.Info)
 		if err != nil {
-			log.Printf("zipper: %v", err)
+			skip(e, err)
 			continue
 		}
-		// UTF-8 filename mode (see Appendix D of ZIP spec)
-		fh.Flags |= (1 << 11)
-		fh.Method = zip.Deflate
-		w, err := zw.CreateHeader(fh)
+		fh.Name = e.Name
+		f, err := store.Open(e.Path)
 		if err != nil {
-			log.Printf("zipper: %v", err)
+			skip(e, err)
 			continue
 		}
-		_, err = io.Copy(w, f)
+		// UTF-8 filename mode (see Appendix D of ZIP spec)
+		fh.Flags |= (1 << 11)
+		br := bufio.NewReaderSize(f, sniffLen)
+		head, _ := br.Peek(sniffLen)
+		fh.Method = zipMethod(fh.Name, e.Info.Size(), head)
+		w, err := zw.CreateHeader(fh)
 		if err != nil {
-			log.Printf("zipper: %v", err)
+			f.Close()
 			break
 		}
+		_, err = io.Copy(w, br)
 		f.Close()
+		if cw.err != nil {
+			break
+		}
+		if err != nil {
+			// what was read of it is in the archive, but not all of it
+			skip(e, fmt.Errorf("incomplete: %v", err))
+		}
+	}
+
+	if len(skipped) > 0 && cw.err == nil {
+		if w, err := zw.Create(zipErrorsName); err == nil {
+			io.WriteString(w, downloadErrors(skipped))
+		}
+	}
+	zw.Close()
+
+	setDownloadStatus(g, "zipper", cw.err, len(skipped))
+}
+
+const (
+	// downloadStatusTrailer ends a streamed zip or tar download with
+	// "complete", "incomplete" if files were left out and listed in
+	// zipErrorsName, or "failed" if the archive was cut short. A download
+	// without it was cut short too.
+	downloadStatusTrailer = "X-Download-Status"
+
+	zipErrorsName = "ERRORS.txt"
+)
+
+// skippedLine logs that e was left out of a download, or is incomplete in it,
+// and returns the line saying so in zipErrorsName.
+func skippedLine(prefix string, e *downloadEntry, err error) string {
+	log.Printf("%s: %v", prefix, err)
+	if pe, ok := err.(*os.PathError); ok {
+		// the local path is none of the client's business
+		err = pe.Err
+	}
+	return fmt.Sprintf("%s: %v\n", e.Name, err)
+}
+
+// downloadErrors is the content of zipErrorsName for the skipped lines.
+func downloadErrors(skipped []string) string {
+	return "These files are missing from the download or incomplete:\n\n" + strings.Join(skipped, "")
+}
+
+// setDownloadStatus sets downloadStatusTrailer once a streamed download has
+// been written, given the error that cut it short, if any, and the number of
+// files left out.
+func setDownloadStatus(g *gas.Gas, prefix string, err error, skipped int) {
+	switch {
+	case err != nil:
+		log.Printf("%s: %v", prefix, err)
+		g.Header().Set(downloadStatusTrailer, "failed")
+	case skipped > 0:
+		g.Header().Set(downloadStatusTrailer, "incomplete")
+	default:
+		g.Header().Set(downloadStatusTrailer, "complete")
+	}
+}
+
+// checkedWriter remembers the first error writing to w, so that failing to
+// send the archive can be told apart from failing to read a file.
+type checkedWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (c *checkedWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
 	}
+	n, err := c.w.Write(p)
+	c.err = err
+	return n, err
 }
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/webdav"
+)
+
+// startTime is when the server started, which stands in as the modification
+// time of the list of mounts.
+var startTime = time.Now()
+
+// options are the settings that can differ between mounts.
+type options struct {
+	ZipFolderEnable          bool
+	ZipFolderEnableRecursive bool
+	GalleryImages            int
+	ReadOnly                 bool // refuse uploads and WebDAV writes
+}
+
+// defaultOptions are the options given in the environment.
+func defaultOptions() options {
+	return options{
+		ZipFolderEnable:          Conf.ZipFolderEnable,
+		ZipFolderEnableRecursive: Conf.ZipFolderEnableRecursive,
+		GalleryImages:            Conf.GalleryImages,
+	}
+}
+
+// A mount is a directory served under /<name>/ instead of Conf.Root.
+type mount struct {
+	name  string
+	root  string
+	opts  options
+	store localStorage
+	cat   *catalog
+}
+
+// mounts are the configured mounts, sorted by name, or nil if
+// INDEX_MOUNTS_FILE isn't set and only Conf.Root is served.
+var mounts []*mount
+
+type byMountName []*mount
+
+func (b byMountName) Len() int           { return len(b) }
+func (b byMountName) Less(i, j int) bool { return b[i].name < b[j].name }
+func (b byMountName) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+
+// loadMounts reads the mounts file. Each line is a name, a directory and
+// options:
+//
+//	photos /srv/photos zip zip-recursive gallery=50
+//	docs   /srv/docs   readonly
+//
+// Options are key=value, and a bare key means true. Anything not given is
+// taken from the environment.
+func loadMounts(file string) ([]*mount, error) {
+	var (
+		ms   []*mount
+		seen = make(map[string]bool)
+	)
+
+	err := readFields(file, "", func(fields []string) error {
+		if len(fields) < 2 {
+			return errors.New("need a name and a directory")
+		}
+		m := &mount{name: fields[0], root: fields[1], opts: defaultOptions()}
+
+		switch {
+		case strings.ContainsAny(m.name, `/\`) || strings.HasPrefix(m.name, "."):
+			return fmt.Errorf("invalid mount name %q", m.name)
+		case m.name == "static":
+			// taken by the static file handler
+			return errors.New(`"static" can't be used as a mount name`)
+		case seen[m.name]:
+			return fmt.Errorf("duplicate mount %q", m.name)
+		}
+		seen[m.name] = true
+
+		fi, err := os.Stat(m.root)
+		if err != nil {
+			return err
+		}
+		if !fi.IsDir() {
+			return fmt.Errorf("%s is not a directory", m.root)
+		}
+
+		for _, opt := range fields[2:] {
+			if err := m.opts.set(opt); err != nil {
+				return err
+			}
+		}
+
+		m.store = localStorage{http.Dir(m.root)}
+		ms = append(ms, m)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(ms) == 0 {
+		return nil, errors.New(file + ": no mounts")
+	}
+
+	sort.Sort(byMountName(ms))
+	return ms, nil
+}
+
+func (o *options) set(opt string) error {
+	key, val := opt, "true"
+	if i := strings.Index(opt, "="); i >= 0 {
+		key, val = opt[:i], opt[i+1:]
+	}
+
+	var err error
+	switch key {
+	case "zip":
+		o.ZipFolderEnable, err = strconv.ParseBool(val)
+	case "zip-recursive":
+		o.ZipFolderEnableRecursive, err = strconv.ParseBool(val)
+	case "gallery":
+		o.GalleryImages, err = strconv.Atoi(val)
+		if err == nil && o.GalleryImages < 1 {
+			err = errors.New("must be at least 1")
+		}
+	case "readonly":
+		o.ReadOnly, err = strconv.ParseBool(val)
+	default:
+		return fmt.Errorf("unknown option %q", key)
+	}
+	if err != nil {
+		return fmt.Errorf("option %s: %v", key, err)
+	}
+	return nil
+}
+
+// findMount returns the mount p is in and p's path within it. A trailing
+// slash on p is kept. It returns nil for the list of mounts itself, and when
+// there are no mounts.
+func findMount(p string) (*mount, string) {
+	p = strings.TrimPrefix(p, "/")
+	name, inner := p, "/"
+	if i := strings.Index(p, "/"); i >= 0 {
+		name, inner = p[:i], p[i:]
+	}
+	for _, m := range mounts {
+		if m.name == name {
+			return m, inner
+		}
+	}
+	return nil, ""
+}
+
+// optionsFor returns the options in effect at the path p.
+func optionsFor(p string) options {
+	if mounts == nil {
+		return defaultOptions()
+	}
+	if m, _ := findMount(p); m != nil {
+		return m.opts
+	}
+	// the list of mounts is not a real directory: there's nothing to zip
+	// and nowhere to upload to
+	opts := defaultOptions()
+	opts.ZipFolderEnable = false
+	opts.ZipFolderEnableRecursive = false
+	opts.ReadOnly = true
+	return opts
+}
+
+// mountStorage serves each mount under its name, and the list of mounts at
+// the top.
+type mountStorage struct{}
+
+func (mountStorage) Open(name string) (http.File, error) {
+	if m, inner := findMount(name); m != nil {
+		return m.store.Open(inner)
+	}
+	if strings.Trim(name, "/") != "" {
+		return nil, os.ErrNotExist
+	}
+	return &memDir{info: dirInfo{"/", startTime}, entries: mountInfos()}, nil
+}
+
+func (mountStorage) LocalPath(name string) string {
+	if m, inner := findMount(name); m != nil {
+		return m.store.LocalPath(inner)
+	}
+	return ""
+}
+
+// mountInfos describes the mounts as the entries of a directory.
+func mountInfos() []os.FileInfo {
+	fis := make([]os.FileInfo, 0, len(mounts))
+	for _, m := range mounts {
+		info := dirInfo{m.name, startTime}
+		if fi, err := os.Stat(m.root); err == nil {
+			info.mod = fi.ModTime()
+		}
+		fis = append(fis, info)
+	}
+	return fis
+}
+
+// davMounts is a WebDAV file system with each mount under its name. The list
+// of mounts can't be changed, and neither can read-only mounts.
+type davMounts struct{}
+
+// resolve returns the file system for the mount name is in and the name within
+// it, or nil for the list of mounts.
+func (davMounts) resolve(name string, write bool) (webdav.FileSystem, string, error) {
+	m, inner := findMount(filepath.ToSlash(name))
+	if m == nil {
+		if strings.Trim(name, "/") != "" {
+			return nil, "", os.ErrNotExist
+		}
+		if write {
+			return nil, "", os.ErrPermission
+		}
+		return nil, "/", nil
+	}
+	if write && (m.opts.ReadOnly || inner == "/") {
+		return nil, "", os.ErrPermission
+	}
+	return webdav.Dir(m.root), inner, nil
+}
+
+func (d davMounts) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	fs, inner, err := d.resolve(name, true)
+	if err != nil {
+		return err
+	}
+	return fs.Mkdir(ctx, inner, perm)
+}
+
+func (d davMounts) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	write := flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND) != 0
+	fs, inner, err := d.resolve(name, write)
+	if err != nil {
+		return nil, err
+	}
+	if fs == nil {
+		return davMountList{&memDir{info: dirInfo{"/", startTime}, entries: mountInfos()}}, nil
+	}
+	return fs.OpenFile(ctx, inner, flag, perm)
+}
+
+func (d davMounts) RemoveAll(ctx context.Context, name string) error {
+	fs, inner, err := d.resolve(name, true)
+	if err != nil {
+		return err
+	}
+	return fs.RemoveAll(ctx, inner)
+}
+
+func (d davMounts) Rename(ctx context.Context, oldName, newName string) error {
+	oldFS, oldInner, err := d.resolve(oldName, true)
+	if err != nil {
+		return err
+	}
+	newFS, newInner, err := d.resolve(newName, true)
+	if err != nil {
+		return err
+	}
+	if oldFS != newFS {
+		// renames can't cross mounts, just like they can't cross devices
+		return os.ErrPermission
+	}
+	return oldFS.Rename(ctx, oldInner, newInner)
+}
+
+func (d davMounts) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	fs, inner, err := d.resolve(name, false)
+	if err != nil {
+		return nil, err
+	}
+	if fs == nil {
+		return dirInfo{"/", startTime}, nil
+	}
+	return fs.Stat(ctx, inner)
+}
+
+// davMountList is the list of mounts opened over WebDAV.
+type davMountList struct {
+	*memDir
+}
+
+func (davMountList) Write([]byte) (int, error) { return 0, os.ErrPermission }
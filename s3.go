@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go"
+)
+
+// s3Storage serves the objects in an S3-compatible bucket (AWS, MinIO, ...)
+// as a tree, treating "/" in object keys as the directory separator.
+type s3Storage struct {
+	client *minio.Client
+	bucket string
+	prefix string // key prefix the tree starts at, "" or ending in "/"
+}
+
+func newS3Storage() (*s3Storage, error) {
+	client, err := minio.New(Conf.S3Endpoint, Conf.S3AccessKey, Conf.S3SecretKey, Conf.S3Secure)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := client.BucketExists(Conf.S3Bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("s3: bucket " + Conf.S3Bucket + " doesn't exist")
+	}
+
+	prefix := strings.Trim(Conf.S3Prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return &s3Storage{client, Conf.S3Bucket, prefix}, nil
+}
+
+func (s *s3Storage) LocalPath(name string) string { return "" }
+
+func (s *s3Storage) key(name string) string {
+	return s.prefix + strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+func (s *s3Storage) Open(name string) (http.File, error) {
+	name = path.Clean("/" + name)
+	key := s.key(name)
+
+	if key != s.prefix {
+		info, err := s.client.StatObject(s.bucket, key, minio.StatObjectOptions{})
+		if err == nil {
+			f := &s3File{info: objectInfo{info, path.Base(name)}}
+			f.open = func() (*minio.Object, error) {
+				return s.client.GetObject(s.bucket, key, minio.GetObjectOptions{})
+			}
+			if f.Object, err = f.open(); err != nil {
+				return nil, err
+			}
+			return f, nil
+		}
+		if minio.ToErrorResponse(err).Code != "NoSuchKey" {
+			return nil, err
+		}
+	}
+
+	// there are no directories in S3, only keys sharing a prefix
+	prefix := key
+	if key != s.prefix {
+		prefix += "/"
+	}
+	entries, found, err := s.list(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if !found && key != s.prefix {
+		return nil, os.ErrNotExist
+	}
+	return &memDir{info: dirInfo{path.Base(name), time.Time{}}, entries: entries}, nil
+}
+
+// list returns the objects and common prefixes directly under prefix, and
+// whether any key starts with it.
+func (s *s3Storage) list(prefix string) (entries []os.FileInfo, found bool, err error) {
+	done := make(chan struct{})
+	defer close(done)
+
+	for info := range s.client.ListObjects(s.bucket, prefix, false, done) {
+		if info.Err != nil {
+			return nil, false, info.Err
+		}
+		found = true
+		name := strings.TrimPrefix(info.Key, prefix)
+		if name == "" {
+			// an empty "directory marker" object for the directory itself
+			continue
+		}
+		entries = append(entries, objectInfo{info, strings.TrimSuffix(name, "/")})
+	}
+	return entries, found, nil
+}
+
+// s3File is an object opened for reading.
+type s3File struct {
+	*minio.Object
+	info   objectInfo
+	open   func() (*minio.Object, error)
+	seeked bool // Object was seeked away from the start
+}
+
+// Seek opens the object again to go back to the start once it was seeked
+// elsewhere, since minio-go keeps asking for the range of the earlier seek.
+func (f *s3File) Seek(offset int64, whence int) (int64, error) {
+	n, err := f.Object.Seek(offset, whence)
+	if err != nil || n != 0 || !f.seeked {
+		f.seeked = f.seeked || n > 0
+		return n, err
+	}
+	obj, err := f.open()
+	if err != nil {
+		return 0, err
+	}
+	f.Object.Close()
+	f.Object, f.seeked = obj, false
+	return 0, nil
+}
+
+func (f *s3File) Stat() (os.FileInfo, error) { return f.info, nil }
+
+func (f *s3File) Readdir(int) ([]os.FileInfo, error) {
+	return nil, errors.New("s3: not a directory")
+}
+
+// objectInfo adapts an object listing to os.FileInfo. Common prefixes (keys
+// ending in "/") are reported as directories.
+type objectInfo struct {
+	info minio.ObjectInfo
+	name string
+}
+
+func (fi objectInfo) Name() string       { return fi.name }
+func (fi objectInfo) Size() int64        { return fi.info.Size }
+func (fi objectInfo) ModTime() time.Time { return fi.info.LastModified }
+func (fi objectInfo) IsDir() bool        { return strings.HasSuffix(fi.info.Key, "/") }
+func (fi objectInfo) Sys() interface{}   { return fi.info }
+
+func (fi objectInfo) Mode() os.FileMode {
+	if fi.IsDir() {
+		return os.ModeDir | 0755
+	}
+	return 0644
+}
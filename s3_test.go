@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeS3 is just enough of the S3 API for s3Storage: bucket location, HEAD
+// and GET of objects, and delimited v1 listings without paging.
+type fakeS3 struct {
+	bucket  string
+	objects map[string][]byte
+	mod     time.Time
+}
+
+type fakeListResult struct {
+	XMLName        xml.Name `xml:"ListBucketResult"`
+	Name           string
+	Prefix         string
+	Delimiter      string
+	MaxKeys        int
+	IsTruncated    bool
+	Contents       []fakeListObject
+	CommonPrefixes []struct{ Prefix string }
+}
+
+type fakeListObject struct {
+	Key          string
+	LastModified string
+	ETag         string
+	Size         int
+	StorageClass string
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	if parts[0] != s.bucket {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if len(parts) == 1 || parts[1] == "" {
+		switch {
+		case r.Method == "HEAD":
+		case r.URL.Query()["location"] != nil:
+			io.WriteString(w, `<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LocationConstraint>`)
+		case r.Method == "GET":
+			s.list(w, r.URL.Query().Get("prefix"), r.URL.Query().Get("delimiter"))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	b, ok := s.objects[parts[1]]
+	if !ok {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusNotFound)
+		if r.Method == "GET" {
+			io.WriteString(w, `<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+		}
+		return
+	}
+	w.Header().Set("ETag", `"`+parts[1]+`"`)
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeContent(w, r, parts[1], s.mod, bytes.NewReader(b))
+}
+
+func (s *fakeS3) list(w http.ResponseWriter, prefix, delim string) {
+	res := fakeListResult{Name: s.bucket, Prefix: prefix, Delimiter: delim, MaxKeys: 1000}
+	var keys []string
+	for k := range s.objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	seen := make(map[string]bool)
+	for _, k := range keys {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		if i := strings.Index(k[len(prefix):], delim); delim != "" && i >= 0 {
+			p := k[:len(prefix)+i+len(delim)]
+			if !seen[p] {
+				seen[p] = true
+				res.CommonPrefixes = append(res.CommonPrefixes, struct{ Prefix string }{p})
+			}
+			continue
+		}
+		res.Contents = append(res.Contents, fakeListObject{
+			Key:          k,
+			LastModified: s.mod.Format("2006-01-02T15:04:05.000Z"),
+			ETag:         `"` + k + `"`,
+			Size:         len(s.objects[k]),
+			StorageClass: "STANDARD",
+		})
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	xml.NewEncoder(w).Encode(res)
+}
+
+// s3Tree serves objects from a fake S3 server and makes it the store, with
+// the tree starting at prefix. done puts the previous store back.
+func s3Tree(t *testing.T, prefix string, objects map[string]string) (done func()) {
+	fake := &fakeS3{
+		bucket:  "photos",
+		objects: make(map[string][]byte),
+		mod:     time.Date(2017, 3, 4, 5, 6, 8, 0, time.UTC),
+	}
+	for k, v := range objects {
+		fake.objects[k] = []byte(v)
+	}
+	srv := httptest.NewServer(fake)
+
+	oldStore, oldConf := store, Conf
+	done = func() {
+		store, Conf = oldStore, oldConf
+		srv.Close()
+	}
+	Conf.S3Endpoint = strings.TrimPrefix(srv.URL, "http://")
+	Conf.S3AccessKey, Conf.S3SecretKey = "key", "secret"
+	Conf.S3Secure = false
+	Conf.S3Bucket = "photos"
+	Conf.S3Prefix = prefix
+	s, err := newS3Storage()
+	if err != nil {
+		done()
+		t.Fatal(err)
+	}
+	store = s
+	return done
+}
+
+var s3Objects = map[string]string{
+	"outside.txt":          "not served",
+	"tree/a.txt":           "hello, world",
+	"tree/empty/":          "",
+	"tree/sub/b.txt":       "b",
+	"tree/sub/deeper/c.md": "c",
+	"treehouse/d.txt":      "not served either",
+}
+
+func TestS3Open(t *testing.T) {
+	done := s3Tree(t, "/tree/", s3Objects)
+	defer done()
+
+	tests := []struct {
+		name    string
+		isDir   bool
+		content string
+		entries []string
+	}{
+		{name: "/", isDir: true, entries: []string{"a.txt", "empty", "sub"}},
+		{name: "/a.txt", content: "hello, world"},
+		{name: "/empty", isDir: true},
+		{name: "/sub", isDir: true, entries: []string{"b.txt", "deeper"}},
+		{name: "/sub/", isDir: true, entries: []string{"b.txt", "deeper"}},
+		{name: "/sub/../sub/deeper", isDir: true, entries: []string{"c.md"}},
+		{name: "/sub/deeper/c.md", content: "c"},
+	}
+	for _, tt := range tests {
+		f, err := store.Open(tt.name)
+		if err != nil {
+			t.Errorf("Open(%q): %v", tt.name, err)
+			continue
+		}
+		fi, err := f.Stat()
+		if err != nil || fi.IsDir() != tt.isDir {
+			t.Errorf("Open(%q).Stat() = %v, %v; want a directory: %v", tt.name, fi, err, tt.isDir)
+		}
+		if tt.isDir {
+			fis, err := f.Readdir(-1)
+			if err != nil {
+				t.Errorf("Open(%q).Readdir: %v", tt.name, err)
+			}
+			var names []string
+			for _, fi := range fis {
+				names = append(names, fi.Name())
+			}
+			sort.Strings(names)
+			if strings.Join(names, " ") != strings.Join(tt.entries, " ") {
+				t.Errorf("Open(%q).Readdir = %q, want %q", tt.name, names, tt.entries)
+			}
+		} else {
+			b, err := ioutil.ReadAll(f)
+			if err != nil || string(b) != tt.content {
+				t.Errorf("reading %q: got %q, %v; want %q", tt.name, b, err, tt.content)
+			}
+			if fi.Size() != int64(len(tt.content)) {
+				t.Errorf("%q: size %d, want %d", tt.name, fi.Size(), len(tt.content))
+			}
+		}
+		f.Close()
+	}
+
+	for _, name := range []string{"/missing", "/sub/missing.txt", "/../outside.txt", "/../treehouse/d.txt", "/house/d.txt"} {
+		if f, err := store.Open(name); !os.IsNotExist(err) {
+			if f != nil {
+				f.Close()
+			}
+			t.Errorf("Open(%q): got %v, want it not to exist", name, err)
+		}
+	}
+}
+
+func TestS3Seek(t *testing.T) {
+	done := s3Tree(t, "", s3Objects)
+	defer done()
+
+	f, err := store.Open("/tree/a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(7, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 5)
+	if _, err := io.ReadFull(f, b); err != nil || string(b) != "world" {
+		t.Errorf("after seeking: got %q, %v; want %q", b, err, "world")
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadFull(f, b); err != nil || string(b) != "hello" {
+		t.Errorf("after seeking back: got %q, %v; want %q", b, err, "hello")
+	}
+}
+
+func TestS3Walk(t *testing.T) {
+	done := s3Tree(t, "tree", s3Objects)
+	defer done()
+
+	entries, err := walk("/sub", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name)
+	}
+	sort.Strings(got)
+	want := []string{"sub", "sub/b.txt", "sub/deeper", "sub/deeper/c.md"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("walk = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ktkr.us/pkg/fmtutil"
+	"ktkr.us/pkg/gas"
+	"ktkr.us/pkg/gas/out"
+)
+
+// errSearchDone stops a search walk once the result cap or time budget has
+// been reached.
+var errSearchDone = errors.New("search done")
+
+// matcher returns a function reporting whether a file name matches a search
+// query. Queries containing glob metacharacters are matched as globs against
+// the whole name; anything else is a substring match. Both are
+// case-insensitive.
+func matcher(query string) func(name string) bool {
+	query = strings.ToLower(query)
+	if strings.ContainsAny(query, "*?[") {
+		return func(name string) bool {
+			ok, _ := filepath.Match(query, strings.ToLower(name))
+			return ok
+		}
+	}
+	return func(name string) bool {
+		return strings.Contains(strings.ToLower(name), query)
+	}
+}
+
+// search walks the tree below root looking for names matching query. It gives
+// up after Conf.SearchMaxResults matches or once Conf.SearchTimeout has
+// elapsed, whichever comes first, and reports whether that happened. Anything
+// user isn't allowed to see is left out.
+func search(root, query, user string) (results []*FileEntry, truncated bool, err error) {
+	var (
+		match    = matcher(query)
+		base     = path.Clean("/" + root)
+		deadline = time.Now().Add(Conf.SearchTimeout)
+	)
+	tf, err := newTreeFilter(root)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = walkTree(root, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			// unreadable subdirectories shouldn't sink the whole search
+			if path.Clean(p) != base && os.IsPermission(err) {
+				return nil
+			}
+			return err
+		}
+		if path.Clean(p) == base {
+			return nil
+		}
+		if tf.hidden(p, fi) || !canAccess(user, p) {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if Conf.SearchTimeout > 0 && time.Now().After(deadline) {
+			truncated = true
+			return errSearchDone
+		}
+		if !match(fi.Name()) {
+			return nil
+		}
+
+		results = append(results, &FileEntry{
+			Component: Component{
+				Name: strings.TrimPrefix(p, strings.TrimSuffix(base, "/")+"/"),
+				Path: (&url.URL{Path: p}).String(),
+			},
+			Size:     fmtutil.SI(fi.Size()),
+			IsDir:    fi.IsDir(),
+			IsLink:   fi.Mode()&os.ModeSymlink != 0,
+			Mod:      fi.ModTime(),
+			FileMode: fi.Mode(),
+		})
+
+		if Conf.SearchMaxResults > 0 && len(results) >= Conf.SearchMaxResults {
+			truncated = true
+			return errSearchDone
+		}
+		return nil
+	})
+
+	if err == errSearchDone {
+		err = nil
+	}
+	return results, truncated, err
+}
+
+func searchIndex(g *gas.Gas, user, query, sortCol string, sortRev bool, format string) (int, gas.Outputter) {
+	results, truncated, err := search(g.URL.Path, query, user)
+	if err != nil {
+		return 500, out.HTML("500", err, "layout")
+	}
+
+	sortEntries(results, sortCol, sortRev)
+
+	data := &struct {
+		Components []Component  `json:"components"`
+		Query      string       `json:"query"`
+		Entries    []*FileEntry `json:"entries"`
+		Truncated  bool         `json:"truncated"`
+		SortCol    string       `json:"sort_col"`
+		SortRev    bool         `json:"sort_rev"`
+		Config     interface{}  `json:"-"`
+	}{
+		breadcrumbs(g.URL.Path),
+		query,
+		results,
+		truncated,
+		sortCol,
+		sortRev,
+		&Conf,
+	}
+
+	g.Header().Add("Vary", "Accept")
+	if wantJSON(g, format) {
+		return 200, out.JSON(data)
+	}
+
+	return 200, out.HTML("search", data, "layout")
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"crypto/tls"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/http2"
+
+	"ktkr.us/pkg/gas"
+)
+
+var (
+	reloadMu  sync.Mutex
+	reloaders []reloader
+)
+
+type reloader struct {
+	name   string
+	reload func() error
+}
+
+// onHangup registers fn to be run each time the process receives SIGHUP.
+func onHangup(name string, fn func() error) {
+	reloadMu.Lock()
+	reloaders = append(reloaders, reloader{name, fn})
+	reloadMu.Unlock()
+}
+
+func reloadOnHangup(sig <-chan os.Signal) {
+	for range sig {
+		reloadMu.Lock()
+		for _, r := range reloaders {
+			if err := r.reload(); err != nil {
+				log.Printf("reloading %s: %v", r.name, err)
+			} else {
+				log.Printf("reloaded %s", r.name)
+			}
+		}
+		reloadMu.Unlock()
+	}
+}
+
+// certLoader holds the TLS certificate being served. It is read from disk
+// again on reload, so renewed certificates are picked up without a restart.
+type certLoader struct {
+	certFile, keyFile string
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+func (l *certLoader) load() error {
+	cert, err := tls.LoadX509KeyPair(l.certFile, l.keyFile)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.cert = &cert
+	l.mu.Unlock()
+	return nil
+}
+
+func (l *certLoader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.cert, nil
+}
+
+// Timeouts of the servers serve starts itself. Requests get long enough to
+// upload something big over a slow connection; responses get as long as they
+// take, since a zip of a big directory can take a while.
+const (
+	readHeaderTimeout = 10 * time.Second
+	readTimeout       = time.Hour
+	idleTimeout       = 2 * time.Minute
+)
+
+// serve serves h, which is r or wraps it, until something goes wrong: over
+// plain HTTP on GAS_PORT, and also over HTTPS (and HTTP/2) on INDEX_TLS_ADDR if
+// a certificate is configured. Without one, r is left to serve itself as it
+// always has, unless something wraps it.
+func serve(r *gas.Router, h http.Handler) error {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go reloadOnHangup(hup)
+
+	plain := &http.Server{
+		Addr:              ":" + strconv.Itoa(gas.Env.Port),
+		Handler:           h,
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+
+	if Conf.TLSCert == "" {
+		if h == http.Handler(r) {
+			return r.Ignition()
+		}
+		log.Printf("listening on %s", plain.Addr)
+		return plain.ListenAndServe()
+	}
+
+	certs := &certLoader{certFile: Conf.TLSCert, keyFile: Conf.TLSKey}
+	if err := certs.load(); err != nil {
+		return err
+	}
+	onHangup("certificate", certs.load)
+
+	srv := &http.Server{
+		Addr:    Conf.TLSAddr,
+		Handler: h,
+		TLSConfig: &tls.Config{
+			GetCertificate: certs.getCertificate,
+			MinVersion:     tls.VersionTLS12,
+		},
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+	if err := http2.ConfigureServer(srv, nil); err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return err
+	}
+
+	if Conf.TLSRedirect {
+		// redirects have no body to wait for
+		plain.Handler = http.HandlerFunc(redirectToTLS)
+		plain.ReadTimeout = readHeaderTimeout
+	}
+	go func() {
+		log.Printf("listening on %s", plain.Addr)
+		log.Fatal(plain.ListenAndServe())
+	}()
+
+	log.Printf("listening on %s (TLS)", srv.Addr)
+	return srv.Serve(tls.NewListener(ln, srv.TLSConfig))
+}
+
+// redirectToTLS sends plain HTTP requests to the same URL over HTTPS.
+func redirectToTLS(w http.ResponseWriter, r *http.Request) {
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		// no port, but maybe an IPv6 address in brackets
+		host = strings.TrimSuffix(strings.TrimPrefix(r.Host, "["), "]")
+	}
+
+	port := "443"
+	if _, p, err := net.SplitHostPort(Conf.TLSAddr); err == nil && p != "" {
+		port = p
+	}
+	// the brackets IPv6 addresses need in URLs come with the port, so the
+	// default port is only dropped afterwards
+	host = strings.TrimSuffix(net.JoinHostPort(host, port), ":443")
+
+	http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+}
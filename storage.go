@@ -0,0 +1,308 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"ktkr.us/pkg/vfs"
+)
+
+// Storage is where the served tree lives. It is a vfs.FileSystem, so paths
+// are slash-separated and rooted at "/", Open and (http.File).Stat follow
+// symlinks, and (http.File).Readdir reports them as links, just like
+// http.Dir. What vfs has no counterpart for is reaching the files on disk,
+// which thumbnails, uploads, WebDAV and the catalog need.
+type Storage interface {
+	vfs.FileSystem
+
+	// LocalPath returns the path on local disk of the file at name, or "" if
+	// the storage isn't on local disk.
+	LocalPath(name string) string
+}
+
+// store is the tree being served.
+var store Storage
+
+var errIsDir = errors.New("is a directory")
+
+// localStorage serves a directory on local disk. Symlinks are followed as far
+// as Conf.Symlinks allows.
+type localStorage struct {
+	http.Dir
+}
+
+func (s localStorage) Open(name string) (http.File, error) {
+	if err := checkSymlinks(string(s.Dir), s.LocalPath(name)); err != nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: err}
+	}
+	return s.Dir.Open(name)
+}
+
+// The values of INDEX_SYMLINKS.
+const (
+	symlinksFollow = "follow" // follow symlinks wherever they lead
+	symlinksWithin = "within" // only follow symlinks to within the root
+	symlinksNever  = "never"  // don't follow symlinks at all
+)
+
+var errSymlink = errors.New("symlink not followed")
+
+// checkSymlinks returns errSymlink if getting to p, a path below root on
+// local disk, takes a symlink Conf.Symlinks doesn't allow following. If p
+// doesn't exist, the way to the closest directory above it that does is
+// checked, since that's where it would be created.
+func checkSymlinks(root, p string) error {
+	if Conf.Symlinks == symlinksFollow {
+		return nil
+	}
+
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+	if p, err = filepath.Abs(p); err != nil {
+		return err
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return err
+	}
+	real, err := filepath.EvalSymlinks(p)
+	for os.IsNotExist(err) && len(p) > len(root) {
+		p = filepath.Dir(p)
+		real, err = filepath.EvalSymlinks(p)
+	}
+	if err != nil {
+		return err
+	}
+
+	if Conf.Symlinks == symlinksNever {
+		// the root itself may be a symlink, but nothing below it
+		rel, err := filepath.Rel(root, p)
+		if err != nil || real != filepath.Join(realRoot, rel) {
+			return errSymlink
+		}
+		return nil
+	}
+	if real != realRoot && !strings.HasPrefix(real, realRoot+string(filepath.Separator)) {
+		return errSymlink
+	}
+	return nil
+}
+
+// isSymlinkErr reports whether err is from refusing to follow a symlink.
+func isSymlinkErr(err error) bool {
+	if pe, ok := err.(*os.PathError); ok {
+		err = pe.Err
+	}
+	return err == errSymlink
+}
+
+func (s localStorage) LocalPath(name string) string {
+	return filepath.Join(string(s.Dir), filepath.FromSlash(path.Clean("/"+name)))
+}
+
+// isLocal reports whether s is on local disk, which some features (the
+// catalog, uploads, WebDAV) need.
+func isLocal(s Storage) bool {
+	// every mount is a directory on local disk, even though the list of
+	// them isn't
+	if _, ok := s.(mountStorage); ok {
+		return true
+	}
+	return s.LocalPath("/") != ""
+}
+
+// newStorage sets up the storage backend selected in the config.
+func newStorage() (Storage, error) {
+	if Conf.S3Bucket != "" {
+		if Conf.MountsFile != "" {
+			return nil, errors.New("INDEX_S3_BUCKET and INDEX_MOUNTS_FILE can't be used together")
+		}
+		return newS3Storage()
+	}
+	if Conf.MountsFile != "" {
+		var err error
+		if mounts, err = loadMounts(Conf.MountsFile); err != nil {
+			return nil, err
+		}
+		return mountStorage{}, nil
+	}
+	return localStorage{http.Dir(Conf.Root)}, nil
+}
+
+// statStorage stats the file at name, following symlinks.
+func statStorage(s Storage, name string) (os.FileInfo, error) {
+	f, err := s.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.Stat()
+}
+
+// readStorageDir lists the directory at name in no particular order.
+func readStorageDir(s Storage, name string) ([]os.FileInfo, error) {
+	f, err := s.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.Readdir(-1)
+}
+
+// walkStorage walks the tree rooted at root in lexical order with the same
+// semantics as filepath.Walk, but with slash-separated storage paths. Like
+// filepath.Walk, it doesn't follow symlinks below root.
+func walkStorage(s Storage, root string, fn filepath.WalkFunc) error {
+	// a trailing slash is what makes an archive a directory, so keep it
+	slash := strings.HasSuffix(root, "/")
+	root = path.Clean("/" + root)
+	if slash && root != "/" {
+		root += "/"
+	}
+
+	fi, err := statStorage(s, root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = walkStorageDir(s, root, fi, fn)
+	}
+	if err == filepath.SkipDir {
+		return nil
+	}
+	return err
+}
+
+func walkStorageDir(s Storage, name string, fi os.FileInfo, fn filepath.WalkFunc) error {
+	if !fi.IsDir() {
+		return fn(name, fi, nil)
+	}
+
+	fis, err := readStorageDir(s, name)
+	err1 := fn(name, fi, err)
+	if err != nil || err1 != nil {
+		return err1
+	}
+
+	sort.Sort(byInfoName(fis))
+	for _, fi := range fis {
+		err := walkStorageDir(s, path.Join(name, fi.Name()), fi, fn)
+		if err != nil {
+			if !fi.IsDir() || err != filepath.SkipDir {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readLink returns the target of the symlink at name. Only local storage has
+// symlinks.
+func readLink(name string) (string, error) {
+	p := store.LocalPath(name)
+	if p == "" {
+		return "", &os.PathError{Op: "readlink", Path: name, Err: errors.New("not on local disk")}
+	}
+	return os.Readlink(p)
+}
+
+// linkTarget returns where the symlink at name points as a path in the served
+// tree, or "" if it points outside of it, so that listings don't give away
+// paths on the server's disk.
+func linkTarget(name string) string {
+	link, err := readLink(name)
+	if err != nil {
+		return ""
+	}
+	root := store.LocalPath("/")
+	if !filepath.IsAbs(link) {
+		link = filepath.Join(filepath.Dir(store.LocalPath(name)), link)
+	}
+	rel, err := filepath.Rel(root, link)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	return path.Join("/", filepath.ToSlash(rel))
+}
+
+// downloadFile copies the file at name into a new temporary file in the
+// remote directory of Conf.ThumbDir, and returns its path. The caller removes
+// it.
+func downloadFile(name string) (string, error) {
+	dir := filepath.Join(Conf.ThumbDir, "remote")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	f, err := store.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	tmp, err := ioutil.TempFile(dir, ".download-")
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(tmp, f)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return tmp.Name(), nil
+}
+
+// memDir is an open directory whose entries are already known, for backends
+// without real directories.
+type memDir struct {
+	info    os.FileInfo
+	entries []os.FileInfo
+	off     int
+}
+
+func (d *memDir) Read([]byte) (int, error)       { return 0, errIsDir }
+func (d *memDir) Seek(int64, int) (int64, error) { return 0, errIsDir }
+func (d *memDir) Close() error                   { return nil }
+func (d *memDir) Stat() (os.FileInfo, error)     { return d.info, nil }
+
+func (d *memDir) Readdir(count int) ([]os.FileInfo, error) {
+	rest := d.entries[d.off:]
+	if count <= 0 {
+		d.off = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if count > len(rest) {
+		count = len(rest)
+	}
+	d.off += count
+	return rest[:count], nil
+}
+
+// dirInfo describes a directory that only exists implicitly, like a key
+// prefix in object storage or a path in an archive without its own entry.
+type dirInfo struct {
+	name string
+	mod  time.Time
+}
+
+func (fi dirInfo) Name() string       { return fi.name }
+func (fi dirInfo) Size() int64        { return 0 }
+func (fi dirInfo) Mode() os.FileMode  { return os.ModeDir | 0755 }
+func (fi dirInfo) ModTime() time.Time { return fi.mod }
+func (fi dirInfo) IsDir() bool        { return true }
+func (fi dirInfo) Sys() interface{}   { return nil }
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLinkTarget(t *testing.T) {
+	dir, _, done := storedZipTree(t, map[string]string{
+		"a/b/file.txt": "",
+	})
+	defer done()
+
+	tests := []struct {
+		link, target, want string
+	}{
+		{"a/rel", "b/file.txt", "/a/b/file.txt"},
+		{"a/b/up", "../../a", "/a"},
+		{"a/root", "..", "/"},
+		{"a/abs", filepath.Join(dir, "a", "b"), "/a/b"},
+		{"a/missing", "nothing/here", "/a/nothing/here"},
+		{"a/out", "../..", ""},
+		{"a/sibling", "../../" + filepath.Base(dir) + "x/secret", ""},
+		{"a/etc", "/etc/passwd", ""},
+	}
+	for _, tt := range tests {
+		if err := os.Symlink(tt.target, filepath.Join(dir, filepath.FromSlash(tt.link))); err != nil {
+			t.Skip(err)
+		}
+		if got := linkTarget("/" + tt.link); got != tt.want {
+			t.Errorf("%s -> %s: linkTarget = %q, want %q", tt.link, tt.target, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,493 @@
+package main
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	_ "golang.org/x/image/bmp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp"
+
+	"ktkr.us/pkg/airlift/thumb"
+)
+
+var errNoThumb = errors.New("no thumbnail could be made")
+
+// thumbSupported reports whether a thumbnail can be made of the file called
+// name.
+func thumbSupported(name string) bool {
+	return thumb.FormatSupported(filepath.Ext(name)) || rasterizerFor(name) != nil
+}
+
+// A thumbSize is the box a thumbnail is scaled to fit.
+type thumbSize struct {
+	width, height int
+}
+
+// thumbSizes are the sizes thumbnails can be asked for with ?t=, each with a
+// variant twice as big for high-DPI screens. ?t=1 is the original size from
+// before there was a choice.
+var thumbSizes = map[string]thumbSize{
+	"1":        {150, 100},
+	"small":    {150, 100},
+	"small2x":  {300, 200},
+	"medium":   {300, 200},
+	"medium2x": {600, 400},
+	"large":    {600, 400},
+	"large2x":  {1200, 800},
+}
+
+// thumbnail returns the path of a thumbnail of the file at name in the served
+// tree, no bigger than size and encoded as tf. Files that aren't images are
+// rasterized first, and so are images that aren't on local disk, into a
+// preview no bigger than the largest thumbnail.
+func thumbnail(name string, fi os.FileInfo, size thumbSize, tf *thumbFormat) (string, error) {
+	p := store.LocalPath(name)
+	r := rasterizerFor(name)
+	if r == nil && p == "" {
+		// the caches need a local file to work from, but not the whole
+		// image
+		r = shrinkImage
+	}
+	if r != nil {
+		var err error
+		if p, err = rasterized(name, fi.ModTime(), r); err != nil {
+			return "", err
+		}
+	}
+	// the cache keeps each size apart
+	if t := tf.cache.Get(p, size.width, size.height); t != "" {
+		return t, nil
+	}
+	return "", errNoThumb
+}
+
+// A thumbFormat is an image format thumbnails are sent in. Each has its own
+// cache, in a directory of Conf.ThumbDir named after it.
+type thumbFormat struct {
+	name  string
+	ctype string
+	enc   thumb.Encoder
+	cache *thumb.Cache
+}
+
+var (
+	jpegThumbs = &thumbFormat{name: "jpeg", ctype: "image/jpeg", enc: flatJPEGEncoder{thumb.JPEGEncoder{Options: &jpeg.Options{Quality: 90}}}}
+	pngThumbs  = &thumbFormat{name: "png", ctype: "image/png", enc: pngEncoder{}}
+
+	// modernThumbs are the formats smaller than JPEG that are sent to
+	// clients that say they take them, best first. They're made by external
+	// programs, and only there if those are installed.
+	modernThumbs []*thumbFormat
+)
+
+// setupThumbFormats makes the caches of the thumbnail formats there are
+// encoders for.
+func setupThumbFormats() error {
+	if Conf.ThumbAvifenc != "" {
+		if p, err := exec.LookPath(Conf.ThumbAvifenc); err != nil {
+			log.Printf("no AVIF thumbnails: %v", err)
+		} else {
+			modernThumbs = append(modernThumbs, &thumbFormat{
+				name:  "avif",
+				ctype: "image/avif",
+				enc:   toolEncoder{p, "{in}", "{out}"},
+			})
+		}
+	}
+	if Conf.ThumbCwebp != "" {
+		if p, err := exec.LookPath(Conf.ThumbCwebp); err != nil {
+			log.Printf("no WebP thumbnails: %v", err)
+		} else {
+			modernThumbs = append(modernThumbs, &thumbFormat{
+				name:  "webp",
+				ctype: "image/webp",
+				enc:   toolEncoder{p, "-quiet", "-q", "80", "{in}", "-o", "{out}"},
+			})
+		}
+	}
+
+	for _, tf := range append(modernThumbs, jpegThumbs, pngThumbs) {
+		dir := filepath.Join(Conf.ThumbDir, tf.name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		c, err := thumb.NewCache(dir, tf.enc, FSStore{}, draw.ApproxBiLinear)
+		if err != nil {
+			return err
+		}
+		tf.cache = c
+		go c.Serve()
+	}
+	return nil
+}
+
+// alphaExts are the image formats that can be transparent, and are rasterized
+// into ones that can be. Their thumbnails are never sent as JPEG.
+var alphaExts = map[string]bool{
+	".png": true, ".gif": true, ".webp": true, ".tif": true, ".tiff": true,
+	".bmp": true, ".ico": true, ".svg": true,
+}
+
+// thumbFormatFor picks the format to send r a thumbnail of the file called
+// name in: the best one it accepts, or else fallbackThumbFormat.
+func thumbFormatFor(r *http.Request, name string) *thumbFormat {
+	for _, tf := range modernThumbs {
+		if accepts(r, tf.ctype) {
+			return tf
+		}
+	}
+	return fallbackThumbFormat(name)
+}
+
+// fallbackThumbFormat is the format for clients that take nothing better:
+// JPEG, or PNG for anything that may be transparent.
+func fallbackThumbFormat(name string) *thumbFormat {
+	if alphaExts[strings.ToLower(filepath.Ext(name))] {
+		return pngThumbs
+	}
+	return jpegThumbs
+}
+
+// flatJPEGEncoder puts transparent images on white before encoding them as
+// JPEG, which would otherwise turn what's see-through black.
+type flatJPEGEncoder struct {
+	thumb.JPEGEncoder
+}
+
+func (e flatJPEGEncoder) Encode(w io.Writer, m image.Image) error {
+	if o, ok := m.(interface {
+		Opaque() bool
+	}); !ok || !o.Opaque() {
+		b := m.Bounds()
+		bg := image.NewRGBA(b)
+		draw.Draw(bg, b, image.White, image.Point{}, draw.Src)
+		draw.Draw(bg, b, m, b.Min, draw.Over)
+		m = bg
+	}
+	return e.JPEGEncoder.Encode(w, m)
+}
+
+type pngEncoder struct{}
+
+func (pngEncoder) Encode(w io.Writer, m image.Image) error {
+	e := png.Encoder{CompressionLevel: png.BestCompression}
+	return e.Encode(w, m)
+}
+
+// A toolEncoder encodes images with an external program, which is given the
+// image as a PNG file {in} and writes what it makes of it to {out}.
+type toolEncoder []string
+
+func (cmd toolEncoder) Encode(w io.Writer, m image.Image) error {
+	dir, err := ioutil.TempDir("", "thumb-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	in, out := filepath.Join(dir, "in.png"), filepath.Join(dir, "out")
+	f, err := os.Create(in)
+	if err != nil {
+		return err
+	}
+	err = png.Encode(f, m)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := runTool(cmd[0], expandArgs(cmd[1:], in, out)...); err != nil {
+		return err
+	}
+	f, err = os.Open(out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// A rasterizer makes an image of a file that isn't one, for thumbnailing: a
+// frame of a video, the first page of a document. It writes a PNG of src to
+// dst, both absolute paths.
+type rasterizer func(src, dst string) error
+
+// rasterizers are the rasterizers for each file extension, in lower case.
+var rasterizers = make(map[string]rasterizer)
+
+func rasterizerFor(name string) rasterizer {
+	return rasterizers[strings.ToLower(filepath.Ext(name))]
+}
+
+// setupRasterizers registers the rasterizers whose tools are available: ffmpeg
+// for videos, pdftoppm for PDFs, and the commands in Conf.ThumbCommandsFile.
+func setupRasterizers() error {
+	if Conf.ThumbFfmpeg != "" {
+		if p, err := exec.LookPath(Conf.ThumbFfmpeg); err != nil {
+			log.Printf("no video thumbnails: %v", err)
+		} else {
+			for _, ext := range videoExts {
+				rasterizers[ext] = videoRasterizer(p)
+			}
+		}
+	}
+	if Conf.ThumbPdftoppm != "" {
+		if p, err := exec.LookPath(Conf.ThumbPdftoppm); err != nil {
+			log.Printf("no PDF thumbnails: %v", err)
+		} else {
+			rasterizers[".pdf"] = pdfRasterizer(p)
+		}
+	}
+	if Conf.ThumbCommandsFile != "" {
+		return loadThumbCommands(Conf.ThumbCommandsFile)
+	}
+	return nil
+}
+
+// rasterizing holds the images rasterized is making, so that a file asked for
+// by several requests at once is only rasterized once.
+var rasterizing = struct {
+	sync.Mutex
+	m map[string]chan struct{}
+}{m: make(map[string]chan struct{})}
+
+// rasterized returns the path of the image r makes of the file at name in the
+// served tree, which was last modified at mod. The images are kept in
+// Conf.ThumbDir, and made again when the file changes. Files from remote
+// storage are downloaded for r and removed again afterwards, since only the
+// image needs keeping.
+func rasterized(name string, mod time.Time, r rasterizer) (string, error) {
+	sum := sha1.Sum([]byte(name))
+	img := filepath.Join(Conf.ThumbDir, "rasterized", hex.EncodeToString(sum[:])+".png")
+
+	for {
+		if cached, err := os.Stat(img); err == nil && cached.ModTime().Equal(mod) {
+			return img, nil
+		}
+		rasterizing.Lock()
+		done := rasterizing.m[img]
+		if done == nil {
+			done = make(chan struct{})
+			rasterizing.m[img] = done
+			rasterizing.Unlock()
+			defer func() {
+				rasterizing.Lock()
+				delete(rasterizing.m, img)
+				rasterizing.Unlock()
+				close(done)
+			}()
+			break
+		}
+		rasterizing.Unlock()
+		// look again once the other one's done; if it failed, this
+		// one tries for itself
+		<-done
+	}
+
+	src := store.LocalPath(name)
+	if src == "" {
+		tmp, err := downloadFile(name)
+		if err != nil {
+			return "", err
+		}
+		defer os.Remove(tmp)
+		src = tmp
+	}
+
+	if err := os.MkdirAll(filepath.Dir(img), 0755); err != nil {
+		return "", err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(img), ".raster-")
+	if err != nil {
+		return "", err
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	// the tools would take a relative path starting with a dash, like
+	// -opw.pdf, for an option
+	src, err = filepath.Abs(src)
+	if err != nil {
+		return "", err
+	}
+	dst, err := filepath.Abs(tmp.Name())
+	if err != nil {
+		return "", err
+	}
+	if err := r(src, dst); err != nil {
+		return "", err
+	}
+	if fi, err := os.Stat(tmp.Name()); err != nil || fi.Size() == 0 {
+		return "", errNoThumb
+	}
+	if err := os.Chtimes(tmp.Name(), mod, mod); err != nil {
+		return "", err
+	}
+	return img, os.Rename(tmp.Name(), img)
+}
+
+// previewSize is the box shrinkImage fits images in: that of the largest
+// thumbnail.
+var previewSize = thumbSizes["large2x"]
+
+// shrinkImage is the rasterizer for images that aren't on local disk, which
+// are downloaded only to be shrunk to previewSize.
+func shrinkImage(src, dst string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	m, _, err := image.Decode(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	b := m.Bounds()
+	if b.Dx() > previewSize.width || b.Dy() > previewSize.height {
+		w, h := previewSize.width, b.Dy()*previewSize.width/b.Dx()
+		if h > previewSize.height {
+			w, h = b.Dx()*previewSize.height/b.Dy(), previewSize.height
+		}
+		dm := image.NewRGBA(image.Rect(0, 0, w, h))
+		draw.ApproxBiLinear.Scale(dm, dm.Bounds(), m, b, draw.Src, nil)
+		m = dm
+	}
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	err = (&png.Encoder{CompressionLevel: png.BestSpeed}).Encode(out, m)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// videoExts are the video formats that get thumbnails.
+var videoExts = []string{
+	".mp4", ".m4v", ".mov", ".mkv", ".webm", ".avi", ".wmv", ".flv",
+	".mpg", ".mpeg", ".ts", ".m2ts", ".3gp", ".ogv",
+}
+
+// videoRasterizer takes a representative frame from a video with ffmpeg.
+// ffmpeg's thumbnail filter does the choosing, skipping over blank and blurry
+// frames.
+func videoRasterizer(ffmpeg string) rasterizer {
+	return func(src, dst string) error {
+		var err error
+		// the first seconds are often black or a title card, so look a
+		// bit later, unless the video is too short for that
+		for _, seek := range []string{"5", "0"} {
+			err = runTool(ffmpeg,
+				"-v", "error", "-nostdin",
+				"-ss", seek, "-i", src,
+				"-vf", "thumbnail", "-frames:v", "1",
+				"-f", "image2", "-c:v", "png", "-y", dst)
+			if fi, serr := os.Stat(dst); err == nil && serr == nil && fi.Size() > 0 {
+				return nil
+			}
+		}
+		return err
+	}
+}
+
+// pdfRasterizer renders the first page of a PDF with poppler's pdftoppm.
+func pdfRasterizer(pdftoppm string) rasterizer {
+	return func(src, dst string) error {
+		// big enough for the largest thumbnail; pdftoppm adds the
+		// extension to the name it's given itself
+		err := runTool(pdftoppm, "-png", "-f", "1", "-l", "1", "-singlefile",
+			"-scale-to", "1200", "--", src, dst)
+		if err != nil {
+			return err
+		}
+		return os.Rename(dst+".png", dst)
+	}
+}
+
+// loadThumbCommands reads the file of commands that rasterize other formats.
+// Each line is a comma-separated list of extensions and the command, in which
+// {in} is replaced by the file to rasterize and {out} by where to write the
+// PNG:
+//
+//	.odt,.docx  /usr/local/bin/doc2png {in} {out}
+func loadThumbCommands(file string) error {
+	return readFields(file, "", func(fields []string) error {
+		if len(fields) < 2 {
+			return errors.New("need extensions and a command")
+		}
+		cmd := fields[1:]
+		if _, err := exec.LookPath(cmd[0]); err != nil {
+			return err
+		}
+		for _, ext := range strings.Split(fields[0], ",") {
+			if !strings.HasPrefix(ext, ".") {
+				return fmt.Errorf("extension %q doesn't start with a dot", ext)
+			}
+			rasterizers[strings.ToLower(ext)] = commandRasterizer(cmd)
+		}
+		return nil
+	})
+}
+
+func commandRasterizer(cmd []string) rasterizer {
+	return func(src, dst string) error {
+		return runTool(cmd[0], expandArgs(cmd[1:], src, dst)...)
+	}
+}
+
+// expandArgs replaces {in} and {out} in a command's arguments.
+func expandArgs(args []string, in, out string) []string {
+	r := strings.NewReplacer("{in}", in, "{out}", out)
+	expanded := make([]string, len(args))
+	for i, arg := range args {
+		expanded[i] = r.Replace(arg)
+	}
+	return expanded
+}
+
+// runTool runs an external program, giving up on it after a minute. Its
+// error is whatever it said on the way out. No more than toolGate allows run
+// at once.
+func runTool(name string, args ...string) error {
+	if toolGate != nil {
+		toolGate.Start()
+		defer toolGate.Done()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	b, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(b)); msg != "" {
+			return fmt.Errorf("%s: %s", filepath.Base(name), msg)
+		}
+		return fmt.Errorf("%s: %v", filepath.Base(name), err)
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"ktkr.us/pkg/gas"
+	"ktkr.us/pkg/gas/out"
+)
+
+var (
+	errTooLarge = errors.New("upload is larger than the size limit")
+	errBadName  = errors.New("invalid file name")
+)
+
+// limitedBody caps how much of a request body is read. Unlike
+// http.MaxBytesReader, it lets the caller tell afterwards whether the limit
+// was hit.
+type limitedBody struct {
+	r   io.Reader
+	n   int64
+	hit bool
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		l.hit = true
+		return 0, errTooLarge
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
+
+func newLimitedBody(r io.Reader) *limitedBody {
+	if Conf.UploadMaxSize <= 0 {
+		return &limitedBody{r: r, n: 1<<63 - 1}
+	}
+	// one extra byte to tell a body of exactly the limit from a bigger one
+	return &limitedBody{r: r, n: Conf.UploadMaxSize + 1}
+}
+
+// authorizeUpload runs the checks shared by every kind of upload. Nothing can
+// be uploaded to read-only mounts. When
+// authentication is configured, only logged-in users can upload, even where
+// anonymous visitors can read.
+func authorizeUpload(g *gas.Gas) (int, bool) {
+	if !Conf.UploadEnable || optionsFor(g.URL.Path).ReadOnly {
+		g.Header().Set("Allow", "GET, HEAD")
+		return 405, false
+	}
+
+	user, code, ok := authorize(g, g.Request, g.URL.Path)
+	if !ok {
+		return code, false
+	}
+	if user == "" && currentACL().enabled() {
+		challenge(g)
+		return 401, false
+	}
+	return 200, true
+}
+
+// postUpload saves the files in a multipart form into the directory at the
+// request path.
+func postUpload(g *gas.Gas) (int, gas.Outputter) {
+	if code, ok := authorizeUpload(g); !ok {
+		return code, out.HTML(strconv.Itoa(code), nil, "layout")
+	}
+
+	dir := store.LocalPath(g.URL.Path)
+	fi, err := statStorage(store, g.URL.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 404, out.HTML("404", err, "layout")
+		} else if isSymlinkErr(err) {
+			return 403, out.HTML("403", err, "layout")
+		}
+		return 500, out.HTML("500", err, "layout")
+	}
+	if !fi.IsDir() {
+		return 400, out.HTML("400", "uploads must be posted to a directory", "layout")
+	}
+	if dir == "" {
+		return notUploadable(g)
+	}
+
+	body := newLimitedBody(g.Request.Body)
+	g.Request.Body = ioutil.NopCloser(body)
+
+	mr, err := g.Request.MultipartReader()
+	if err != nil {
+		return 400, out.HTML("400", err, "layout")
+	}
+
+	var (
+		overwrite = g.URL.Query().Get("overwrite") == "1"
+		saved     []string
+	)
+
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return uploadError(g, body, err)
+		}
+
+		// the checkbox in the upload form comes before the file input, so it
+		// is seen before any files
+		if part.FileName() == "" {
+			if part.FormName() == "overwrite" {
+				v, _ := ioutil.ReadAll(io.LimitReader(part, 8))
+				overwrite = string(v) == "1"
+			}
+			continue
+		}
+
+		name, err := uploadName(part.FileName())
+		if err != nil {
+			return 400, out.HTML("400", err, "layout")
+		}
+		if _, err := saveUpload(dir, name, part, overwrite); err != nil {
+			return uploadError(g, body, err)
+		}
+		saved = append(saved, name)
+	}
+
+	if wantJSON(g, "") {
+		return 200, out.JSON(struct {
+			Saved []string `json:"saved"`
+		}{saved})
+	}
+	return 303, out.Redirect(g.URL.Path)
+}
+
+// putUpload saves the request body as the file at the request path.
+func putUpload(g *gas.Gas) (int, gas.Outputter) {
+	if code, ok := authorizeUpload(g); !ok {
+		return code, out.HTML(strconv.Itoa(code), nil, "layout")
+	}
+
+	p := path.Clean("/" + g.URL.Path)
+	name, err := uploadName(path.Base(p))
+	if err != nil {
+		return 400, out.HTML("400", err, "layout")
+	}
+
+	dir := store.LocalPath(path.Dir(p))
+	fi, err := statStorage(store, path.Dir(p))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 404, out.HTML("404", err, "layout")
+		} else if isSymlinkErr(err) {
+			return 403, out.HTML("403", err, "layout")
+		}
+		return 500, out.HTML("500", err, "layout")
+	}
+	if !fi.IsDir() {
+		return 400, out.HTML("400", "parent is not a directory", "layout")
+	}
+	if dir == "" {
+		return notUploadable(g)
+	}
+
+	body := newLimitedBody(g.Request.Body)
+	replaced, err := saveUpload(dir, name, body, g.URL.Query().Get("overwrite") == "1")
+	if err != nil {
+		return uploadError(g, body, err)
+	}
+
+	if replaced {
+		g.WriteHeader(204)
+	} else {
+		g.WriteHeader(201)
+	}
+	return g.Stop()
+}
+
+// notUploadable refuses uploads to directories that aren't on local disk,
+// like those inside an archive being browsed.
+func notUploadable(g *gas.Gas) (int, gas.Outputter) {
+	g.Header().Set("Allow", "GET, HEAD")
+	return 405, out.HTML("405", nil, "layout")
+}
+
+func uploadError(g *gas.Gas, body *limitedBody, err error) (int, gas.Outputter) {
+	switch {
+	case body.hit:
+		return 413, out.HTML("413", errTooLarge, "layout")
+	case os.IsExist(err):
+		return 409, out.HTML("409", err, "layout")
+	}
+	return 500, out.HTML("500", err, "layout")
+}
+
+// uploadName checks that a client-supplied file name names a visible file
+// directly inside the target directory.
+func uploadName(name string) (string, error) {
+	// browsers on Windows have been known to send the whole path
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	if name == "" || strings.HasPrefix(name, ".") {
+		return "", errBadName
+	}
+	return name, nil
+}
+
+// saveUpload writes r to a temporary file in dir and then moves it to name in
+// one step, so nobody ever sees a partial upload. Unless overwrite is set, it
+// fails if name already exists.
+func saveUpload(dir, name string, r io.Reader, overwrite bool) (replaced bool, err error) {
+	if dir == "" {
+		// name would be taken relative to the working directory
+		return false, errors.New("upload directory isn't on local disk")
+	}
+	dst := filepath.Join(dir, name)
+
+	// the temporary file is a dotfile, so it stays out of listings
+	tmp, err := ioutil.TempFile(dir, ".upload-")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return false, err
+	}
+	if err := tmp.Close(); err != nil {
+		return false, err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return false, err
+	}
+
+	if overwrite {
+		_, err := os.Lstat(dst)
+		replaced = err == nil
+		return replaced, os.Rename(tmp.Name(), dst)
+	}
+
+	// unlike rename, link refuses to replace an existing file
+	return false, os.Link(tmp.Name(), dst)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ktkr.us/pkg/gas"
+)
+
+func TestUploadIntoArchive(t *testing.T) {
+	root, err := ioutil.TempDir("", "upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	// the server's working directory, where a path inside an archive would
+	// otherwise end up
+	cwd, err := ioutil.TempDir("", "cwd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cwd)
+	oldwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(cwd); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(oldwd)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, _ := zw.Create("inner/x.txt")
+	w.Write([]byte("x"))
+	zw.Close()
+	if err := ioutil.WriteFile(filepath.Join(root, "a.zip"), buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldStore, oldConf := store, Conf
+	defer func() { store, Conf = oldStore, oldConf }()
+	Conf.UploadEnable = true
+	Conf.ArchiveBrowse = true
+	Conf.Symlinks = symlinksFollow
+	store = newArchiveStorage(localStorage{http.Dir(root)})
+
+	put := func(p string) *http.Request {
+		return httptest.NewRequest("PUT", p+"?overwrite=1", bytes.NewReader([]byte("evil")))
+	}
+	post := func(p string) *http.Request {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fw, _ := mw.CreateFormFile("file", "evil.txt")
+		fw.Write([]byte("evil"))
+		mw.Close()
+		r := httptest.NewRequest("POST", p+"?overwrite=1", &body)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+		return r
+	}
+
+	tests := []struct {
+		r       *http.Request
+		handler func(*gas.Gas) (int, gas.Outputter)
+	}{
+		{put("/a.zip/inner/evil.txt"), putUpload},
+		{post("/a.zip/"), postUpload},
+		{post("/a.zip/inner"), postUpload},
+	}
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		code, _ := tt.handler(&gas.Gas{ResponseWriter: rr, Request: tt.r})
+		if code != 405 {
+			t.Errorf("%s %s: got %d, want 405", tt.r.Method, tt.r.URL.Path, code)
+		}
+	}
+
+	for _, dir := range []string{cwd, root} {
+		if _, err := os.Stat(filepath.Join(dir, "evil.txt")); err == nil {
+			t.Errorf("upload into an archive was saved in %s", dir)
+		}
+	}
+}
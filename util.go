@@ -1,8 +1,15 @@
 package main
 
 import (
+	"net/http"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"syscall"
+
+	"ktkr.us/pkg/gas"
 )
 
 type FSStore struct{}
@@ -37,8 +44,72 @@ func (l byModTime) Less(i, j int) bool {
 }
 
 type Component struct {
-	Name string
-	Path string
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// sortEntries sorts a listing in place by the column named in the s query
+// parameter. Unknown columns leave the listing in directory order.
+func sortEntries(entries []*FileEntry, col string, rev bool) {
+	var sorter sort.Interface
+
+	switch col {
+	case "n":
+		sorter = byName(entries)
+	case "s":
+		sorter = bySize(entries)
+	case "m":
+		sorter = byModTime(entries)
+	default:
+		return
+	}
+
+	if rev {
+		sorter = sort.Reverse(sorter)
+	}
+
+	sort.Stable(sorter)
+}
+
+// entryError describes err, from looking at an entry in a listing, for the
+// listing. It doesn't give away where the served directory is on disk.
+func entryError(err error, isLink bool) string {
+	switch {
+	case isSymlinkErr(err):
+		return "link not followed"
+	case isLink && os.IsNotExist(err):
+		return "broken link"
+	case os.IsPermission(err):
+		return "permission denied"
+	}
+	if pe, ok := err.(*os.PathError); ok {
+		err = pe.Err
+	}
+	if errno, ok := err.(syscall.Errno); ok && errno == syscall.EIO {
+		return "I/O error"
+	}
+	return err.Error()
+}
+
+// breadcrumbs splits a request path into links to each of its ancestors,
+// starting at the root.
+func breadcrumbs(urlPath string) []Component {
+	relPath := strings.Trim(urlPath, "/")
+	if relPath == "" {
+		return []Component{{"/", "/"}}
+	}
+
+	parts := strings.Split(relPath, string([]rune{filepath.Separator}))
+	components := make([]Component, len(parts)+1)
+	components[0] = Component{"/", "/"}
+	for i, p := range parts {
+		components[i+1] = Component{p + "/", browsePath("/" + filepath.Join(parts[:i+1]...))}
+	}
+	if mounts != nil {
+		// inside a mount, the path starts at the mount
+		return components[1:]
+	}
+	return components
 }
 
 var readmePatterns = []string{
@@ -66,3 +137,33 @@ func determineReadmeKind(fi os.FileInfo) int {
 	}
 	return notReadme
 }
+
+// wantJSON reports whether the client asked for a JSON representation, either
+// explicitly with ?format=json or through the Accept header.
+func wantJSON(g *gas.Gas, format string) bool {
+	if format != "" {
+		return format == "json"
+	}
+	return accepts(g.Request, "application/json")
+}
+
+// accepts reports whether the Accept header of r names ctype, and doesn't
+// refuse it with q=0. Wildcards don't count: the point is to find out about
+// formats the client was made to understand.
+func accepts(r *http.Request, ctype string) bool {
+	for _, v := range strings.Split(r.Header.Get("Accept"), ",") {
+		params := strings.Split(v, ";")
+		if strings.TrimSpace(params[0]) != ctype {
+			continue
+		}
+		for _, p := range params[1:] {
+			if q := strings.TrimSpace(p); strings.HasPrefix(q, "q=") {
+				if f, err := strconv.ParseFloat(q[2:], 64); err == nil && f == 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
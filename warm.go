@@ -0,0 +1,186 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// warmProgressInterval is how often warmThumbs reports how far it's got.
+const warmProgressInterval = 10 * time.Second
+
+// prefetchQueueLen is how many files can wait to have their thumbnails
+// prefetched. Any more are made when they're asked for instead.
+const prefetchQueueLen = 100
+
+// A thumbJob is a thumbnail to make before it's asked for.
+type thumbJob struct {
+	name string // path in the served tree
+	size thumbSize
+	tf   *thumbFormat
+}
+
+func (j thumbJob) run() error {
+	fi, err := statStorage(store, j.name)
+	if err != nil {
+		return err
+	}
+	_, err = thumbnail(j.name, fi, j.size, j.tf)
+	return err
+}
+
+// galleryThumbJobs returns the jobs that make the thumbnails a gallery shows of
+// the file at name, in each of formats: both the normal and the 2x size, since
+// which one a browser picks depends on its screen. They're done one after
+// another, so that a file that has to be rasterized is only rasterized once.
+func galleryThumbJobs(name string, formats []*thumbFormat) []thumbJob {
+	var jobs []thumbJob
+	for _, s := range []string{Conf.ThumbSize, Conf.ThumbSize + "2x"} {
+		for _, tf := range formats {
+			jobs = append(jobs, thumbJob{name, thumbSizes[s], tf})
+		}
+	}
+	return jobs
+}
+
+// thumbFormats returns every format a thumbnail of the file called name may
+// be sent in, whatever the client.
+func thumbFormats(name string) []*thumbFormat {
+	return append(modernThumbs[:len(modernThumbs):len(modernThumbs)], fallbackThumbFormat(name))
+}
+
+func thumbWorkers() int {
+	if Conf.ThumbWarmWorkers > 0 {
+		return Conf.ThumbWarmWorkers
+	}
+	return runtime.NumCPU()
+}
+
+// warmThumbs makes the gallery thumbnails of every file in the served tree
+// that can have one, in every format they might be sent in, so that no one
+// has to wait for them. Thumbnails already in the cache are quick to skip,
+// so it's cheap to run again. Files are queued as the walk finds them, so
+// work starts right away and the tree is never held in memory.
+func warmThumbs() error {
+	tf, err := newTreeFilter("/")
+	if err != nil {
+		return err
+	}
+
+	var (
+		queue                = make(chan []thumbJob)
+		queued, done, failed int64
+		files                int
+		wg                   sync.WaitGroup
+		start                = time.Now()
+		progress             = time.NewTicker(warmProgressInterval)
+	)
+	defer progress.Stop()
+	log.Printf("warm: making thumbnails with %d workers", thumbWorkers())
+
+	for i := 0; i < thumbWorkers(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for jobs := range queue {
+				for _, j := range jobs {
+					if err := j.run(); err != nil {
+						log.Printf("warm: %s: %v", j.name, err)
+						atomic.AddInt64(&failed, 1)
+					}
+					atomic.AddInt64(&done, 1)
+				}
+			}
+		}()
+	}
+
+	err = walkTree("/", func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if path.Clean(p) != "/" && os.IsPermission(err) {
+				return nil
+			}
+			return err
+		}
+		if tf.hidden(p, fi) {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if fi.IsDir() || !thumbSupported(fi.Name()) {
+			return nil
+		}
+
+		jobs := galleryThumbJobs(p, thumbFormats(fi.Name()))
+		files++
+		queued += int64(len(jobs))
+		for {
+			select {
+			case queue <- jobs:
+				return nil
+			case <-progress.C:
+				log.Printf("warm: %d of %d thumbnails found so far done", atomic.LoadInt64(&done), queued)
+			}
+		}
+	})
+	close(queue)
+	if err == nil {
+		log.Printf("warm: found %d thumbnails of %d files to make", queued, files)
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	for waiting := true; waiting; {
+		select {
+		case <-finished:
+			waiting = false
+		case <-progress.C:
+			log.Printf("warm: %d of %d thumbnails done", atomic.LoadInt64(&done), queued)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Printf("warm: %d thumbnails done in %v, %d failed", done, time.Since(start), failed)
+	return nil
+}
+
+var prefetchQueue chan []thumbJob
+
+// startPrefetch starts the workers that make the thumbnails queued by
+// prefetchThumbs.
+func startPrefetch() {
+	prefetchQueue = make(chan []thumbJob, prefetchQueueLen)
+	for i := 0; i < thumbWorkers(); i++ {
+		go func() {
+			for jobs := range prefetchQueue {
+				for _, j := range jobs {
+					if err := j.run(); err != nil {
+						log.Printf("prefetch: %s: %v", j.name, err)
+					}
+				}
+			}
+		}()
+	}
+}
+
+// prefetchThumbs queues the thumbnails of a file to be made in the
+// background. It never waits: if the queue is full, they're left to be made
+// when they're asked for, and prefetchThumbs reports false.
+func prefetchThumbs(jobs []thumbJob) bool {
+	select {
+	case prefetchQueue <- jobs:
+		return true
+	default:
+		return false
+	}
+}
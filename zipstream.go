@@ -0,0 +1,562 @@
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// When every file in a zip download is stored rather than compressed, where
+// each byte of the archive comes from is known before anything is read. Such a
+// zip is served as a virtual file with http.ServeContent, which gives it a
+// Content-Length and lets interrupted downloads resume with Range requests.
+//
+// The one thing that isn't known up front is the CRC-32 of each file, which
+// goes in a data descriptor after the file's data and again in the central
+// directory at the end. CRCs are computed as files are sent and remembered, so
+// resuming a download doesn't mean reading everything before the resume point
+// again.
+
+const (
+	uint16max = 1<<16 - 1
+	uint32max = 1<<32 - 1
+
+	zipFlags = 0x8 | 0x800 // data descriptor follows the data; UTF-8 names
+
+	localHeaderLen    = 30
+	dataDescriptorLen = 16 // 24 with zip64 sizes
+	dirHeaderLen      = 46
+	dirEndLen         = 22
+	dir64EndLen       = 56
+	dir64LocLen       = 20
+	extTimeLen        = 9 // extended timestamp extra field with just mtime
+)
+
+// errShrunk is the error for a file that's shorter than it was when a stored
+// zip was laid out. The zip can't be finished, since its length has been
+// promised already, so it's cut short to show that something went wrong.
+var errShrunk = errors.New("file shrank while being downloaded")
+
+// storedZip is the layout of a zip download in which nothing is compressed.
+type storedZip struct {
+	files []*storedFile
+	parts []zipPart
+	size  int64
+	etag  string
+
+	dirOnce sync.Once
+	dir     []byte // central directory and end records, built on demand
+	dirErr  error
+}
+
+type storedFile struct {
+	*zip.FileHeader
+	path   string // in the served tree
+	size   int64
+	offset int64 // of the local file header
+
+	crcOnce sync.Once
+	crc     uint32
+	crcErr  error
+}
+
+func (f *storedFile) zip64() bool { return f.size >= uint32max }
+
+type zipPartKind int
+
+const (
+	zipBytes      zipPartKind = iota // fixed bytes: a local file header
+	zipData                          // a file's contents
+	zipDescriptor                    // a data descriptor, which needs the CRC
+	zipDirectory                     // the central directory, which needs all CRCs
+)
+
+type zipPart struct {
+	kind  zipPartKind
+	off   int64
+	size  int64
+	file  *storedFile
+	bytes []byte
+}
+
+// planStoredZip lays out a zip of the regular files among entries if none of
+// them would be compressed and all of them can be opened, and returns nil
+// otherwise. Files whose type can't be told from their name have their first
+// bytes read to decide.
+func planStoredZip(entries []*downloadEntry) *storedZip {
+	z := new(storedZip)
+	etag := sha1.New()
+
+	for _, e := range entries {
+		if !e.Info.Mode().IsRegular() {
+			continue
+		}
+		if !storedInZip(e) {
+			return nil
+		}
+
+		fh, err := zip.FileInfoHeader(e.Info)
+		if err != nil {
+			return nil
+		}
+		fh.Name = e.Name
+		fh.Flags = zipFlags
+		fh.Method = zip.Store
+
+		f := &storedFile{FileHeader: fh, path: e.Path, size: e.Info.Size(), offset: z.size}
+		z.files = append(z.files, f)
+
+		z.add(zipPart{kind: zipBytes, bytes: localHeader(f)})
+		z.add(zipPart{kind: zipData, size: f.size, file: f})
+		n := int64(dataDescriptorLen)
+		if f.zip64() {
+			n += 8
+		}
+		z.add(zipPart{kind: zipDescriptor, size: n, file: f})
+
+		io.WriteString(etag, e.Name)
+		binary.Write(etag, binary.LittleEndian, []int64{f.size, e.Info.ModTime().UnixNano()})
+	}
+
+	z.add(zipPart{kind: zipDirectory, size: z.directorySize()})
+	z.etag = `"` + hex.EncodeToString(etag.Sum(nil)) + `"`
+	return z
+}
+
+// storedInZip reports whether e would be stored in a zip and can be opened.
+// A stored zip has no room to say a file was left out, so one that can't be
+// opened has to be found while planning.
+func storedInZip(e *downloadEntry) bool {
+	f, err := store.Open(e.Path)
+	if err != nil {
+		// only a streamed zip can leave it out and say so
+		return false
+	}
+	defer f.Close()
+
+	if m, ok := zipMethodByName(e.Name, e.Info.Size()); ok {
+		return m == zip.Store
+	}
+	head, _ := bufio.NewReaderSize(f, sniffLen).Peek(sniffLen)
+	return zipMethodBySniff(head) == zip.Store
+}
+
+func (z *storedZip) add(p zipPart) {
+	if p.bytes != nil {
+		p.size = int64(len(p.bytes))
+	}
+	p.off = z.size
+	z.parts = append(z.parts, p)
+	z.size += p.size
+}
+
+// dosTime converts t to the MS-DOS date and time used in zip headers.
+func dosTime(t time.Time) (date, tm uint16) {
+	if t.Year() < 1980 {
+		t = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	date = uint16(t.Day() + int(t.Month())<<5 + (t.Year()-1980)<<9)
+	tm = uint16(t.Second()/2 + t.Minute()<<5 + t.Hour()<<11)
+	return date, tm
+}
+
+// extTime is an extended timestamp extra field holding the modification
+// time, which is more precise than the MS-DOS one and has a time zone.
+func extTime(t time.Time) []byte {
+	b := make([]byte, extTimeLen)
+	binary.LittleEndian.PutUint16(b, 0x5455)
+	binary.LittleEndian.PutUint16(b[2:], 5)
+	b[4] = 1 // mtime only
+	secs := t.Unix()
+	if secs < 0 || secs > uint32max {
+		secs = 0
+	}
+	binary.LittleEndian.PutUint32(b[5:], uint32(secs))
+	return b
+}
+
+func version(zip64 bool) uint16 {
+	if zip64 {
+		return 45
+	}
+	return 20
+}
+
+// localHeader is the header before each file's data. The CRC and sizes are
+// left for the data descriptor after the data, like a zip written in one pass.
+func localHeader(f *storedFile) []byte {
+	b := make([]byte, localHeaderLen, localHeaderLen+len(f.Name)+extTimeLen)
+	date, tm := dosTime(f.Modified)
+	le := binary.LittleEndian
+	le.PutUint32(b, 0x04034b50)
+	le.PutUint16(b[4:], version(f.zip64()))
+	le.PutUint16(b[6:], zipFlags)
+	le.PutUint16(b[8:], zip.Store)
+	le.PutUint16(b[10:], tm)
+	le.PutUint16(b[12:], date)
+	// CRC and sizes (b[14:26]) stay zero
+	le.PutUint16(b[26:], uint16(len(f.Name)))
+	le.PutUint16(b[28:], extTimeLen)
+	b = append(b, f.Name...)
+	return append(b, extTime(f.Modified)...)
+}
+
+func dataDescriptor(f *storedFile, crc uint32) []byte {
+	b := make([]byte, 0, dataDescriptorLen+8)
+	b = appendUint32(b, 0x08074b50)
+	b = appendUint32(b, crc)
+	if f.zip64() {
+		b = appendUint64(b, uint64(f.size))
+		b = appendUint64(b, uint64(f.size))
+	} else {
+		b = appendUint32(b, uint32(f.size))
+		b = appendUint32(b, uint32(f.size))
+	}
+	return b
+}
+
+// zip64Extra returns the zip64 extra field for f's central directory header,
+// holding whichever of its sizes and offset don't fit in 32 bits.
+func zip64Extra(f *storedFile) []byte {
+	var fields []uint64
+	if f.size >= uint32max {
+		// uncompressed and compressed size are the same
+		fields = append(fields, uint64(f.size), uint64(f.size))
+	}
+	if f.offset >= uint32max {
+		fields = append(fields, uint64(f.offset))
+	}
+	if fields == nil {
+		return nil
+	}
+
+	b := make([]byte, 0, 4+8*len(fields))
+	b = appendUint16(b, 0x0001)
+	b = appendUint16(b, uint16(8*len(fields)))
+	for _, v := range fields {
+		b = appendUint64(b, v)
+	}
+	return b
+}
+
+func (z *storedZip) usesZip64() bool {
+	for _, f := range z.files {
+		if f.size >= uint32max || f.offset >= uint32max {
+			return true
+		}
+	}
+	return false
+}
+
+// dirStart is where the central directory starts, right after the last file.
+func (z *storedZip) dirStart() int64 {
+	return z.parts[len(z.parts)-1].off
+}
+
+// directorySize works out the length of the central directory and end records
+// without the CRCs that go in them.
+func (z *storedZip) directorySize() int64 {
+	var size int64
+	for _, f := range z.files {
+		size += int64(dirHeaderLen + len(f.Name) + len(zip64Extra(f)) + extTimeLen)
+	}
+	if z.needsDir64End(z.size, size) {
+		size += dir64EndLen + dir64LocLen
+	}
+	return size + dirEndLen
+}
+
+// needsDir64End reports whether the zip64 end records are needed, given where
+// the central directory starts and how long it is.
+func (z *storedZip) needsDir64End(dirStart, dirSize int64) bool {
+	return z.usesZip64() || len(z.files) >= uint16max || dirSize >= uint32max || dirStart >= uint32max
+}
+
+func (z *storedZip) buildDirectory() ([]byte, error) {
+	var (
+		b     []byte
+		start = z.dirStart()
+	)
+
+	for _, f := range z.files {
+		crc, err := z.crc(f)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			extra     = append(zip64Extra(f), extTime(f.Modified)...)
+			zip64     = f.size >= uint32max || f.offset >= uint32max
+			date, tm  = dosTime(f.Modified)
+			size      = uint32(min64(f.size, uint32max))
+			offset    = uint32(min64(f.offset, uint32max))
+			creatorOS = f.CreatorVersion &^ 0xff
+		)
+		b = appendUint32(b, 0x02014b50)
+		b = appendUint16(b, creatorOS|version(zip64))
+		b = appendUint16(b, version(zip64))
+		b = appendUint16(b, zipFlags)
+		b = appendUint16(b, zip.Store)
+		b = appendUint16(b, tm)
+		b = appendUint16(b, date)
+		b = appendUint32(b, crc)
+		b = appendUint32(b, size)
+		b = appendUint32(b, size)
+		b = appendUint16(b, uint16(len(f.Name)))
+		b = appendUint16(b, uint16(len(extra)))
+		b = appendUint16(b, 0) // comment length
+		b = appendUint16(b, 0) // disk number
+		b = appendUint16(b, 0) // internal attributes
+		b = appendUint32(b, f.ExternalAttrs)
+		b = appendUint32(b, offset)
+		b = append(b, f.Name...)
+		b = append(b, extra...)
+	}
+
+	var (
+		end     = start + int64(len(b))
+		records = uint64(len(z.files))
+		size    = uint64(len(b))
+	)
+
+	if z.needsDir64End(start, int64(len(b))) {
+		b = appendUint32(b, 0x06064b50)
+		b = appendUint64(b, dir64EndLen-12)
+		b = appendUint16(b, 45)
+		b = appendUint16(b, 45)
+		b = appendUint32(b, 0)
+		b = appendUint32(b, 0)
+		b = appendUint64(b, records)
+		b = appendUint64(b, records)
+		b = appendUint64(b, size)
+		b = appendUint64(b, uint64(start))
+
+		b = appendUint32(b, 0x07064b50)
+		b = appendUint32(b, 0)
+		b = appendUint64(b, uint64(end))
+		b = appendUint32(b, 1)
+	}
+
+	b = appendUint32(b, 0x06054b50)
+	b = appendUint16(b, 0)
+	b = appendUint16(b, 0)
+	b = appendUint16(b, uint16(min64(int64(records), uint16max)))
+	b = appendUint16(b, uint16(min64(int64(records), uint16max)))
+	b = appendUint32(b, uint32(min64(int64(size), uint32max)))
+	b = appendUint32(b, uint32(min64(start, uint32max)))
+	b = appendUint16(b, 0) // comment length
+	return b, nil
+}
+
+// crc returns the CRC-32 of f's data as it is sent, cut off at the size it
+// was listed with if it has grown since.
+func (z *storedZip) crc(f *storedFile) (uint32, error) {
+	f.crcOnce.Do(func() {
+		key := crcKey{f.path, f.size, f.Modified.UnixNano()}
+		if crc, ok := crcCache.get(key); ok {
+			f.crc = crc
+			return
+		}
+
+		r, err := store.Open(f.path)
+		if err != nil {
+			f.crcErr = err
+			return
+		}
+		defer r.Close()
+
+		h := crc32.NewIEEE()
+		if _, err := io.CopyN(h, r, f.size); err != nil {
+			if err == io.EOF {
+				err = &os.PathError{Op: "read", Path: f.path, Err: errShrunk}
+			}
+			f.crcErr = err
+			return
+		}
+		f.crc = h.Sum32()
+		crcCache.put(key, f.crc)
+	})
+	return f.crc, f.crcErr
+}
+
+// reader returns a reader over the whole zip for http.ServeContent.
+func (z *storedZip) reader() *zipReader {
+	return &zipReader{z: z}
+}
+
+// zipReader reads a storedZip. It keeps the file it last read from open, so
+// reading straight through opens each file once, and computes the CRCs of
+// files it reads from start to end on the way.
+type zipReader struct {
+	z   *storedZip
+	pos int64
+
+	file   *storedFile
+	f      http.File
+	fpos   int64       // where in file's data f is
+	hash   hash.Hash32 // CRC of file's data up to fpos, if read from the start
+	hashed bool
+}
+
+func (r *zipReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.pos
+	case io.SeekEnd:
+		offset += r.z.size
+	}
+	if offset < 0 {
+		return r.pos, errors.New("zip: negative position")
+	}
+	r.pos = offset
+	return r.pos, nil
+}
+
+func (r *zipReader) Read(p []byte) (int, error) {
+	if r.pos >= r.z.size {
+		r.close()
+		return 0, io.EOF
+	}
+
+	i := sort.Search(len(r.z.parts), func(i int) bool {
+		return r.z.parts[i].off+r.z.parts[i].size > r.pos
+	})
+	part := &r.z.parts[i]
+	within := r.pos - part.off
+
+	var (
+		n   int
+		err error
+	)
+	switch part.kind {
+	case zipBytes:
+		n = copy(p, part.bytes[within:])
+	case zipData:
+		n, err = r.readData(part.file, within, p[:min64(int64(len(p)), part.size-within)])
+	case zipDescriptor:
+		var crc uint32
+		if crc, err = r.z.crc(part.file); err == nil {
+			n = copy(p, dataDescriptor(part.file, crc)[within:])
+		}
+	case zipDirectory:
+		r.z.dirOnce.Do(func() { r.z.dir, r.z.dirErr = r.z.buildDirectory() })
+		if err = r.z.dirErr; err == nil {
+			n = copy(p, r.z.dir[within:])
+		}
+	}
+	r.pos += int64(n)
+	return n, err
+}
+
+// readData reads file's data starting at off. p must not go past the end of
+// the data.
+func (r *zipReader) readData(file *storedFile, off int64, p []byte) (int, error) {
+	if r.file != file || r.fpos != off {
+		r.close()
+		f, err := store.Open(file.path)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := f.Seek(off, io.SeekStart); err != nil {
+			f.Close()
+			return 0, err
+		}
+		r.file, r.f, r.fpos = file, f, off
+		r.hash, r.hashed = crc32.NewIEEE(), off == 0
+	}
+
+	n, err := r.f.Read(p)
+	if r.hashed {
+		r.hash.Write(p[:n])
+	}
+	r.fpos += int64(n)
+
+	if err == io.EOF {
+		if r.fpos < file.size {
+			err = &os.PathError{Op: "read", Path: file.path, Err: errShrunk}
+			log.Printf("zip: %v, download cut short", err)
+			r.close()
+			return n, err
+		}
+		err = nil
+	}
+
+	if r.fpos == file.size {
+		if r.hashed {
+			crc := r.hash.Sum32()
+			crcCache.put(crcKey{file.path, file.size, file.Modified.UnixNano()}, crc)
+			file.crcOnce.Do(func() { file.crc = crc })
+		}
+		r.close()
+	}
+	return n, err
+}
+
+func (r *zipReader) close() {
+	if r.f != nil {
+		r.f.Close()
+	}
+	r.file, r.f = nil, nil
+}
+
+type crcKey struct {
+	path string
+	size int64
+	mod  int64
+}
+
+// crcCacheMax bounds the number of remembered CRCs. When it's reached the
+// cache starts over, which only costs some rereading.
+const crcCacheMax = 1 << 20
+
+var crcCache = &crcMap{m: make(map[crcKey]uint32)}
+
+type crcMap struct {
+	mu sync.Mutex
+	m  map[crcKey]uint32
+}
+
+func (c *crcMap) get(k crcKey) (uint32, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	crc, ok := c.m[k]
+	return crc, ok
+}
+
+func (c *crcMap) put(k crcKey, crc uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.m) >= crcCacheMax {
+		c.m = make(map[crcKey]uint32)
+	}
+	c.m[k] = crc
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func appendUint16(b []byte, v uint16) []byte { return append(b, byte(v), byte(v>>8)) }
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	return appendUint32(appendUint32(b, uint32(v)), uint32(v>>32))
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// storedZipTree serves a temporary directory holding files, and returns the
+// download entries for them, as for a zip of the whole directory. done puts
+// everything back as it was.
+func storedZipTree(t *testing.T, files map[string]string) (dir string, entries []*downloadEntry, done func()) {
+	dir, err := ioutil.TempDir("", "zipstream")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldStore, oldLevel, oldSymlinks := store, Conf.ZipCompressLevel, Conf.Symlinks
+	store = localStorage{http.Dir(dir)}
+	Conf.ZipCompressLevel = 0 // store everything
+	Conf.Symlinks = symlinksFollow
+	crcCache = &crcMap{m: make(map[crcKey]uint32)}
+	done = func() {
+		store, Conf.ZipCompressLevel, Conf.Symlinks = oldStore, oldLevel, oldSymlinks
+		os.RemoveAll(dir)
+	}
+
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	mod := time.Date(2017, 3, 4, 5, 6, 8, 0, time.UTC)
+	for _, name := range names {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(files[name]), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(p, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, storedZipEntry(t, name))
+	}
+	return dir, entries, done
+}
+
+func storedZipEntry(t *testing.T, name string) *downloadEntry {
+	fi, err := statStorage(store, "/"+name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := newDownloadEntry(name, "/"+name, fi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+// zipAt reads a stored zip at any offset, each read through a reader of its
+// own, like a download resumed with a Range request.
+type zipAt struct {
+	z *storedZip
+}
+
+func (a zipAt) ReadAt(p []byte, off int64) (int, error) {
+	r := a.z.reader()
+	if _, err := r.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, err := io.ReadFull(r, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func checkZipContents(t *testing.T, ra io.ReaderAt, size int64, want map[string]string) {
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(zr.File) != len(want) {
+		t.Errorf("zip has %d files, want %d", len(zr.File), len(want))
+	}
+	for _, zf := range zr.File {
+		w, ok := want[zf.Name]
+		if !ok {
+			t.Errorf("unexpected file %q in zip", zf.Name)
+			continue
+		}
+		if zf.Method != zip.Store {
+			t.Errorf("%s: method %d, want stored", zf.Name, zf.Method)
+		}
+		if !zf.Modified.Equal(time.Date(2017, 3, 4, 5, 6, 8, 0, time.UTC)) {
+			t.Errorf("%s: modified %v", zf.Name, zf.Modified)
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			t.Errorf("%s: %v", zf.Name, err)
+			continue
+		}
+		// reading to the end checks the CRC
+		b, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Errorf("%s: %v", zf.Name, err)
+		} else if string(b) != w {
+			t.Errorf("%s: got %q, want %q", zf.Name, b, w)
+		}
+	}
+}
+
+var storedZipFiles = map[string]string{
+	"a.txt":            "hello, world\n",
+	"empty":            "",
+	"dir/sub/ünï.json": strings.Repeat(`{"x": 1}`, 10000),
+}
+
+func TestStoredZipRoundTrip(t *testing.T) {
+	_, entries, done := storedZipTree(t, storedZipFiles)
+	defer done()
+	z := planStoredZip(entries)
+	if z == nil {
+		t.Fatal("zip wasn't planned as stored")
+	}
+
+	b, err := ioutil.ReadAll(z.reader())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int64(len(b)) != z.size {
+		t.Fatalf("read %d bytes, but the zip was planned to be %d", len(b), z.size)
+	}
+	checkZipContents(t, bytes.NewReader(b), z.size, storedZipFiles)
+}
+
+func TestStoredZipRanges(t *testing.T) {
+	_, entries, done := storedZipTree(t, storedZipFiles)
+	defer done()
+	whole, err := ioutil.ReadAll(planStoredZip(entries).reader())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	z := planStoredZip(entries)
+	offsets := []int64{
+		z.size - 1,
+		z.dirStart(),
+		z.dirStart() - 3, // within the last data descriptor
+		z.parts[1].off + 5,
+		z.size / 2,
+		1,
+		0,
+	}
+	// CRCs aren't known yet at the first offsets, so they have to be worked
+	// out on the way
+	crcCache = &crcMap{m: make(map[crcKey]uint32)}
+	for _, off := range offsets {
+		r := z.reader()
+		if _, err := r.Seek(off, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatalf("from %d: %v", off, err)
+		}
+		if !bytes.Equal(b, whole[off:]) {
+			t.Errorf("reading from %d doesn't match the whole zip", off)
+		}
+	}
+}
+
+func TestStoredZipTailFirst(t *testing.T) {
+	_, entries, done := storedZipTree(t, storedZipFiles)
+	defer done()
+	z := planStoredZip(entries)
+	// zip.NewReader starts with the end records and central directory,
+	// which need every CRC before any file has been read
+	checkZipContents(t, zipAt{z}, z.size, storedZipFiles)
+}
+
+func TestStoredZip64(t *testing.T) {
+	if testing.Short() {
+		t.Skip("reads a 4 GiB file")
+	}
+
+	const bigSize = 1<<32 + 1<<20
+	dir, entries, done := storedZipTree(t, map[string]string{"small": "before"})
+	defer done()
+	// a sparse file takes no room, and reads as zeros
+	f, err := os.Create(filepath.Join(dir, "big"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = f.Truncate(bigSize)
+	f.Close()
+	if err != nil {
+		t.Skip("no sparse files here:", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "tail"), []byte("after"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// so that the last file's offset is past 4 GiB too
+	entries = append(entries, storedZipEntry(t, "big"), storedZipEntry(t, "tail"))
+
+	z := planStoredZip(entries)
+	if !z.usesZip64() {
+		t.Fatal("zip64 wasn't used")
+	}
+
+	zr, err := zip.NewReader(zipAt{z}, z.size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(zr.File) != 3 {
+		t.Fatalf("zip has %d files, want 3", len(zr.File))
+	}
+	for _, zf := range zr.File {
+		switch zf.Name {
+		case "big":
+			if zf.UncompressedSize64 != bigSize {
+				t.Errorf("big: size %d, want %d", zf.UncompressedSize64, uint64(bigSize))
+			}
+		case "small", "tail":
+			rc, err := zf.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := ioutil.ReadAll(rc)
+			rc.Close()
+			want := map[string]string{"small": "before", "tail": "after"}[zf.Name]
+			if err != nil || string(b) != want {
+				t.Errorf("%s: got %q, %v; want %q", zf.Name, b, err, want)
+			}
+		default:
+			t.Errorf("unexpected file %q in zip", zf.Name)
+		}
+	}
+}
+
+func TestStoredZipShrunk(t *testing.T) {
+	files := map[string]string{"a": "0123456789", "b": "abcdefghij"}
+	dir, entries, done := storedZipTree(t, files)
+	defer done()
+	z := planStoredZip(entries)
+	if err := os.Truncate(filepath.Join(dir, "a"), 4); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadAll(z.reader())
+	if pe, ok := err.(*os.PathError); !ok || pe.Err != errShrunk {
+		t.Fatalf("got error %v, want %v", err, errShrunk)
+	}
+	if int64(len(b)) >= z.size {
+		t.Errorf("read all %d bytes of a zip with a file that shrank", len(b))
+	}
+
+	// resuming after it doesn't get any further
+	r := z.reader()
+	r.Seek(z.dirStart(), io.SeekStart)
+	if _, err := ioutil.ReadAll(r); err == nil {
+		t.Error("the central directory was read without the shrunk file's CRC")
+	}
+}
+
+func TestStoredZipUnreadable(t *testing.T) {
+	dir, entries, done := storedZipTree(t, storedZipFiles)
+	defer done()
+	if err := os.Remove(filepath.Join(dir, "a.txt")); err != nil {
+		t.Fatal(err)
+	}
+	// a stored zip couldn't say the file was left out
+	if z := planStoredZip(entries); z != nil {
+		t.Error("zip was planned as stored without one of its files")
+	}
+}